@@ -0,0 +1,239 @@
+// Command abi encodes and decodes ABI data from the shell.
+//
+// Usage:
+//
+//	abi [--json] <command> [--json] [arguments]
+//
+// Commands:
+//
+//	encode <types> <values...>          encode values, i.e. `abi encode uint256,address 1 0x5ff1...`
+//	encode-packed <types> <values...>   encode values with packed encoding
+//	calldata <sig> <args...>            encode a function call, i.e. `abi calldata "transfer(address,uint256)" 0x5ff1... 1000`
+//	decode <types> <hex>                decode data
+//	decode-calldata <sig> <hex>         decode a function call
+//	selector <sig>                      print the 4-byte function selector
+//	topic <event-sig>                   print the 32-byte event topic
+//
+// The decode and decode-calldata commands decode like abi.DecodeTyped
+// and abi.FormatCall rather than abi.Decode and abi.DecodeWithSignature,
+// so that addresses, bytesN and parameter names are printed as such.
+//
+// Types are comma separated, i.e. `uint256,(address,bytes)[]`. Values
+// are parsed by abi.ParseValue: integers (i.e. `1000`, `0x3e8` or `1e18`),
+// addresses, booleans, 0x-prefixed hex bytes, strings, `[..]` arrays and
+// `(..)` tuples, i.e. `[(0x5ff1...,0x1234)]`.
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/omnes-tech/abi"
+)
+
+const usage = `usage: abi [--json] <command> [--json] [arguments]
+
+commands:
+  encode <types> <values...>         encode values
+  encode-packed <types> <values...>  encode values with packed encoding
+  calldata <sig> <args...>           encode a function call
+  decode <types> <hex>               decode data
+  decode-calldata <sig> <hex>        decode a function call
+  selector <sig>                     print the 4-byte function selector
+  topic <event-sig>                  print the 32-byte event topic
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "abi:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command given by args, writing its result to w.
+func run(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("abi", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	jsonOutput := flags.Bool("json", false, "print JSON output")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%v\n%v", err, usage)
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%v", usage)
+	}
+
+	// Flags are also accepted between the command and its arguments,
+	// i.e. `abi topic --json <event-sig>`.
+	command := args[0]
+	if err := flags.Parse(args[1:]); err != nil {
+		return fmt.Errorf("%v\n%v", err, usage)
+	}
+	args = flags.Args()
+
+	out := output{w: w, json: *jsonOutput}
+	switch command {
+	case "encode", "encode-packed":
+		if len(args) < 1 {
+			return fmt.Errorf("usage: abi %v <types> <values...>", command)
+		}
+		return encode(out, command == "encode-packed", args[0], args[1:])
+	case "calldata":
+		if len(args) < 1 {
+			return fmt.Errorf("usage: abi calldata <sig> <args...>")
+		}
+		return calldata(out, args[0], args[1:])
+	case "decode":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: abi decode <types> <hex>")
+		}
+		return decode(out, args[0], args[1])
+	case "decode-calldata":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: abi decode-calldata <sig> <hex>")
+		}
+		return decodeCalldata(out, args[0], args[1])
+	case "selector":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: abi selector <sig>")
+		}
+		return out.hex("selector", abi.EncodeSignature(args[0]))
+	case "topic":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: abi topic <event-sig>")
+		}
+		return out.hex("topic", abi.EncodeEventTopic(args[0]))
+	default:
+		return fmt.Errorf("unknown command %q\n%v", command, usage)
+	}
+}
+
+// encode encodes literal values of given comma separated types.
+func encode(out output, packed bool, typesStr string, literals []string) error {
+	typeStrs := abi.SplitParams(typesStr)
+	values, err := abi.ParseValues(typeStrs, literals)
+	if err != nil {
+		return err
+	}
+
+	var encoded []byte
+	if packed {
+		encoded, err = abi.EncodePacked(typeStrs, values...)
+	} else {
+		encoded, err = abi.Encode(typeStrs, values...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return out.hex("data", encoded)
+}
+
+// calldata encodes a function call with literal arguments.
+func calldata(out output, funcSignature string, literals []string) error {
+	typeStrs, err := abi.GetSigTypes(funcSignature)
+	if err != nil {
+		return err
+	}
+
+	values, err := abi.ParseValues(typeStrs, literals)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := abi.EncodeWithSignature(funcSignature, values...)
+	if err != nil {
+		return err
+	}
+
+	return out.hex("data", encoded)
+}
+
+// decode decodes hex data to given comma separated types.
+func decode(out output, typesStr string, hexData string) error {
+	data, err := parseHex(hexData)
+	if err != nil {
+		return err
+	}
+
+	typeStrs := abi.SplitParams(typesStr)
+	values, err := abi.DecodeTyped(typeStrs, data)
+	if err != nil {
+		return err
+	}
+
+	formatted, err := abi.FormatValues(typeStrs, values)
+	if err != nil {
+		return err
+	}
+
+	if out.json {
+		return out.writeJSON(formatted)
+	}
+
+	for _, value := range formatted {
+		fmt.Fprint(out.w, value)
+	}
+
+	return nil
+}
+
+// decodeCalldata decodes a function call from hex data.
+func decodeCalldata(out output, funcSignature string, hexData string) error {
+	data, err := parseHex(hexData)
+	if err != nil {
+		return err
+	}
+
+	call, err := abi.FormatCall(funcSignature, data)
+	if err != nil {
+		return err
+	}
+
+	if out.json {
+		return out.writeJSON(call)
+	}
+
+	fmt.Fprint(out.w, call)
+	return nil
+}
+
+// output writes command results as text or JSON.
+type output struct {
+	w    io.Writer
+	json bool
+}
+
+// hex writes bytes as 0x-prefixed hex, or as a JSON object with given key.
+func (o output) hex(key string, b []byte) error {
+	if o.json {
+		return o.writeJSON(map[string]string{key: "0x" + hex.EncodeToString(b)})
+	}
+
+	_, err := fmt.Fprintf(o.w, "0x%x\n", b)
+	return err
+}
+
+// writeJSON writes v as indented JSON.
+func (o output) writeJSON(v any) error {
+	encoder := json.NewEncoder(o.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// parseHex parses hex data, with or without 0x prefix.
+func parseHex(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(s), "0x"), "0X")
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex data: %v", err)
+	}
+
+	return data, nil
+}
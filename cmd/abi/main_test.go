@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func Example_calldata() {
+	err := run([]string{"calldata", "transfer(address,uint256)", "0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789", "1000"}, os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output: 0xa9059cbb0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d278900000000000000000000000000000000000000000000000000000000000003e8
+}
+
+func Example_encode() {
+	err := run([]string{"encode", "(address,uint8[])[],string", `[(0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789,[1,0x2])]`, `"a, b"`}, os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	err = run([]string{"encode-packed", "int8,bytes2,bool", "-1", "0x1234", "true"}, os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// 0x00000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000120000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000200000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d278900000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000004612c206200000000000000000000000000000000000000000000000000000000
+	// 0xff123401
+}
+
+func Example_decode() {
+	err := run([]string{"decode", "address,uint8[]", "0x0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d27890000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002"}, os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// [0] (address): 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789
+	// [1] (uint8[]):
+	//   [0] (uint8): 1 (0x1)
+	//   [1] (uint8): 2 (0x2)
+}
+
+func Example_decodeCalldata() {
+	err := run([]string{"--json", "decode-calldata", "transfer(address to,uint256 amount)", "0xa9059cbb0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d278900000000000000000000000000000000000000000000000000000000000003e8"}, os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// {
+	//   "function": "transfer",
+	//   "signature": "transfer(address,uint256)",
+	//   "selector": "0xa9059cbb",
+	//   "params": [
+	//     {
+	//       "name": "to",
+	//       "type": "address",
+	//       "value": "0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"
+	//     },
+	//     {
+	//       "name": "amount",
+	//       "type": "uint256",
+	//       "value": "1000"
+	//     }
+	//   ]
+	// }
+}
+
+func Example_selector() {
+	if err := run([]string{"selector", "transfer(address,uint256)"}, os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+
+	if err := run([]string{"topic", "--json", "Transfer(address,address,uint256)"}, os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+
+	if err := run([]string{"--json", "selector", "-json=false", "--", "transfer(address,uint256)"}, os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// 0xa9059cbb
+	// {
+	//   "topic": "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	// }
+	// 0xa9059cbb
+}
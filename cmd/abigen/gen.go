@@ -0,0 +1,664 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+// goStruct is a generated Go struct.
+type goStruct struct {
+	Name    string
+	Comment string
+	Fields  []goField
+}
+
+// goField is a field of a generated Go struct, or a parameter of a
+// generated function.
+type goField struct {
+	Name    string // Go name
+	ABIName string // tuple component name, for the abi tag
+	Type    string // Go type
+	TypeStr string // ABI type string with named tuple components
+}
+
+// generator generates Go bindings for a contract ABI.
+type generator struct {
+	buf        bytes.Buffer
+	imports    map[string]bool
+	structs    []*goStruct
+	structKeys map[string]*goStruct // by named type string
+	names      map[string]bool      // declared top-level names
+}
+
+// generate generates the Go source of the bindings of given contract.
+func generate(contract *abi.Contract, pkg string) ([]byte, error) {
+	g := &generator{
+		imports:    map[string]bool{"github.com/omnes-tech/abi": true},
+		structKeys: make(map[string]*goStruct),
+		names:      make(map[string]bool),
+	}
+
+	var body bytes.Buffer
+	functions := sortedEntries(contract.Functions, "Function", func(method *abi.Method) string { return method.Name })
+	for _, function := range functions {
+		if err := g.function(&body, function.name, function.entry); err != nil {
+			return nil, err
+		}
+	}
+
+	events := sortedEntries(contract.Events, "Event", func(event *abi.Event) string { return event.Name })
+	for _, event := range events {
+		if err := g.event(&body, event.name, event.entry); err != nil {
+			return nil, err
+		}
+	}
+
+	errors := sortedEntries(contract.Errors, "Error", func(abiError *abi.Error) string { return abiError.Name })
+	for _, abiError := range errors {
+		if err := g.customError(&body, abiError.name, abiError.entry); err != nil {
+			return nil, err
+		}
+	}
+	if len(errors) > 0 {
+		if err := g.decodeError(&body, errors); err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Fprintf(&g.buf, "// Code generated by github.com/omnes-tech/abi/cmd/abigen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&g.buf, "package %v\n\n", pkg)
+
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+
+	g.buf.WriteString("import (\n")
+	for _, std := range []bool{true, false} {
+		for _, path := range imports {
+			if !strings.Contains(path, ".") == std {
+				fmt.Fprintf(&g.buf, "\t%q\n", path)
+			}
+		}
+		if std {
+			g.buf.WriteString("\n")
+		}
+	}
+	g.buf.WriteString(")\n\n")
+
+	for _, s := range g.structs {
+		if len(s.Fields) == 0 {
+			fmt.Fprintf(&g.buf, "// %v\ntype %v struct{}\n\n", s.Comment, s.Name)
+			continue
+		}
+
+		fmt.Fprintf(&g.buf, "// %v\ntype %v struct {\n", s.Comment, s.Name)
+		for _, field := range s.Fields {
+			if field.ABIName != "" {
+				fmt.Fprintf(&g.buf, "\t%v %v `abi:%q`\n", field.Name, field.Type, field.ABIName)
+			} else {
+				fmt.Fprintf(&g.buf, "\t%v %v\n", field.Name, field.Type)
+			}
+		}
+		g.buf.WriteString("}\n\n")
+	}
+
+	g.buf.Write(body.Bytes())
+
+	source, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %v", err)
+	}
+
+	return source, nil
+}
+
+// function generates the selector, the encoder and the output decoder
+// of a function.
+func (g *generator) function(w *bytes.Buffer, name string, method *abi.Method) error {
+	inputs := g.fields(method.Inputs, name, false)
+	params := make([]string, len(inputs))
+	args := make([]string, len(inputs))
+	for i, input := range inputs {
+		params[i] = input.Name + " " + input.Type
+		args[i] = ", " + input.Name
+	}
+
+	if err := g.declare(name+"Selector", "Encode"+name); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "// %vSelector is the selector of %v.\n", name, method.Signature)
+	fmt.Fprintf(w, "var %vSelector = common.Hex2Bytes(%q)\n\n", name, common.Bytes2Hex(method.Selector))
+	g.imports["github.com/ethereum/go-ethereum/common"] = true
+
+	fmt.Fprintf(w, "// Encode%v encodes a call to %v.\n", name, method.Signature)
+	fmt.Fprintf(w, "func Encode%v(%v) ([]byte, error) {\n", name, strings.Join(params, ", "))
+	if len(inputs) == 0 {
+		fmt.Fprintf(w, "\treturn append([]byte{}, %vSelector...), nil\n}\n\n", name)
+	} else {
+		fmt.Fprintf(w, "\tencoded, err := abi.MarshalValues(%v%v)\n", typeStrsLiteral(inputs), strings.Join(args, ""))
+		fmt.Fprintf(w, "\tif err != nil {\n\t\treturn nil, err\n\t}\n\n")
+		fmt.Fprintf(w, "\treturn append(append([]byte{}, %vSelector...), encoded...), nil\n}\n\n", name)
+	}
+
+	if len(method.Outputs) == 0 {
+		return nil
+	}
+
+	outputs := g.fields(method.Outputs, name+"Output", true)
+	if err := g.declare("Decode" + name + "Output"); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "// Decode%vOutput decodes the output of %v.\n", name, method.Signature)
+
+	if len(outputs) == 1 {
+		fmt.Fprintf(w, "func Decode%vOutput(data []byte) (%v, error) {\n", name, outputs[0].Type)
+		fmt.Fprintf(w, "\tvar out %v\n", outputs[0].Type)
+		fmt.Fprintf(w, "\terr := abi.UnmarshalValues(%v, data, &out)\n", typeStrsLiteral(outputs))
+		fmt.Fprintf(w, "\treturn out, err\n}\n\n")
+		return nil
+	}
+
+	outputStruct := name + "Output"
+	if err := g.declare(outputStruct); err != nil {
+		return err
+	}
+	g.structs = append(g.structs, &goStruct{
+		Name:    outputStruct,
+		Comment: fmt.Sprintf("%v is the output of %v.", outputStruct, method.Signature),
+		Fields:  untagged(outputs),
+	})
+
+	refs := make([]string, len(outputs))
+	for i, output := range outputs {
+		refs[i] = ", &out." + output.Name
+	}
+
+	fmt.Fprintf(w, "func Decode%vOutput(data []byte) (*%v, error) {\n", name, outputStruct)
+	fmt.Fprintf(w, "\tout := new(%v)\n", outputStruct)
+	fmt.Fprintf(w, "\tif err := abi.UnmarshalValues(%v, data%v); err != nil {\n\t\treturn nil, err\n\t}\n\n", typeStrsLiteral(outputs), strings.Join(refs, ""))
+	fmt.Fprintf(w, "\treturn out, nil\n}\n\n")
+	return nil
+}
+
+// event generates the topic, the struct and the log decoder of an
+// event.
+func (g *generator) event(w *bytes.Buffer, name string, event *abi.Event) error {
+	fields := g.fields(event.Inputs, name+"Event", true)
+	for i, input := range event.Inputs {
+		t, _ := abi.ParseType(input.Type)
+		if input.Indexed && (t.IsDynamic() || t.Kind == abi.ArrayKind || t.Kind == abi.TupleKind) {
+			fields[i].Type = "common.Hash"
+		}
+	}
+
+	topicCount := 1
+	if event.Anonymous {
+		topicCount = 0
+	}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			topicCount++
+		}
+	}
+
+	eventStruct := name + "Event"
+	if err := g.declare(eventStruct, "Decode"+name); err != nil {
+		return err
+	}
+	g.structs = append(g.structs, &goStruct{
+		Name:    eventStruct,
+		Comment: fmt.Sprintf("%v is the %v event.", eventStruct, event.Signature),
+		Fields:  untagged(fields),
+	})
+
+	g.imports["fmt"] = true
+	g.imports["github.com/ethereum/go-ethereum/common"] = true
+
+	if !event.Anonymous {
+		if err := g.declare(name + "Topic"); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "// %vTopic is the topic of the %v event.\n", name, event.Signature)
+		fmt.Fprintf(w, "var %vTopic = common.Hex2Bytes(%q)\n\n", name, common.Bytes2Hex(event.Topic))
+	}
+
+	fmt.Fprintf(w, "// Decode%v decodes a %v event log.\n", name, event.Signature)
+	fmt.Fprintf(w, "func Decode%v(topics [][]byte, data []byte) (*%v, error) {\n", name, eventStruct)
+	fmt.Fprintf(w, "\tif len(topics) != %d {\n", topicCount)
+	fmt.Fprintf(w, "\t\treturn nil, fmt.Errorf(\"topic count mismatch for %v: expected %d topics, got %%d\", len(topics))\n\t}\n\n", event.Signature, topicCount)
+
+	topic := 0
+	if !event.Anonymous {
+		g.imports["bytes"] = true
+		fmt.Fprintf(w, "\tif !bytes.Equal(topics[0], %vTopic) {\n", name)
+		fmt.Fprintf(w, "\t\treturn nil, fmt.Errorf(\"topic0 mismatch for %v\")\n\t}\n\n", event.Signature)
+		topic = 1
+	}
+
+	fmt.Fprintf(w, "\tevent := new(%v)\n", eventStruct)
+
+	var dataFields []goField
+	for i, input := range event.Inputs {
+		field := fields[i]
+		if !input.Indexed {
+			dataFields = append(dataFields, field)
+			continue
+		}
+
+		if field.Type == "common.Hash" {
+			fmt.Fprintf(w, "\tevent.%v = common.BytesToHash(topics[%d])\n", field.Name, topic)
+		} else {
+			fmt.Fprintf(w, "\tif err := abi.UnmarshalValues([]string{%q}, topics[%d], &event.%v); err != nil {\n\t\treturn nil, err\n\t}\n", field.TypeStr, topic, field.Name)
+		}
+		topic++
+	}
+
+	if len(dataFields) > 0 {
+		refs := make([]string, len(dataFields))
+		for i, field := range dataFields {
+			refs[i] = ", &event." + field.Name
+		}
+		fmt.Fprintf(w, "\tif err := abi.UnmarshalValues(%v, data%v); err != nil {\n\t\treturn nil, err\n\t}\n", typeStrsLiteral(dataFields), strings.Join(refs, ""))
+	}
+
+	fmt.Fprintf(w, "\n\treturn event, nil\n}\n\n")
+	return nil
+}
+
+// customError generates the selector, the error type and the decoder
+// of a custom error.
+func (g *generator) customError(w *bytes.Buffer, name string, abiError *abi.Error) error {
+	errorType := name + "Error"
+	fields := g.fields(abiError.Inputs, errorType, true)
+
+	if err := g.declare(errorType, errorType+"Selector", "Decode"+errorType); err != nil {
+		return err
+	}
+	g.structs = append(g.structs, &goStruct{
+		Name:    errorType,
+		Comment: fmt.Sprintf("%v is the %v custom error.", errorType, abiError.Signature),
+		Fields:  untagged(fields),
+	})
+
+	g.imports["bytes"] = true
+	g.imports["fmt"] = true
+	g.imports["github.com/ethereum/go-ethereum/common"] = true
+
+	fmt.Fprintf(w, "// %vSelector is the selector of the %v custom error.\n", errorType, abiError.Signature)
+	fmt.Fprintf(w, "var %vSelector = common.Hex2Bytes(%q)\n\n", errorType, common.Bytes2Hex(abiError.Selector))
+
+	formats := make([]string, len(fields))
+	args := make([]string, len(fields))
+	for i, field := range fields {
+		label := abiError.Inputs[i].Name
+		if label == "" {
+			label = field.Name
+		}
+		formats[i] = strings.ReplaceAll(label, "%", "%%") + ": %v"
+		args[i] = ", e." + field.Name
+	}
+
+	fmt.Fprintf(w, "// Error implements the error interface.\n")
+	fmt.Fprintf(w, "func (e *%v) Error() string {\n", errorType)
+	if len(fields) == 0 {
+		fmt.Fprintf(w, "\treturn %q\n}\n\n", abiError.Name+"()")
+	} else {
+		fmt.Fprintf(w, "\treturn fmt.Sprintf(%q%v)\n}\n\n", abiError.Name+"("+strings.Join(formats, ", ")+")", strings.Join(args, ""))
+	}
+
+	refs := make([]string, len(fields))
+	for i, field := range fields {
+		refs[i] = ", &e." + field.Name
+	}
+
+	fmt.Fprintf(w, "// Decode%v decodes %v revert data.\n", errorType, abiError.Signature)
+	fmt.Fprintf(w, "func Decode%v(data []byte) (*%v, error) {\n", errorType, errorType)
+	fmt.Fprintf(w, "\tif len(data) < 4 || !bytes.Equal(data[:4], %vSelector) {\n", errorType)
+	fmt.Fprintf(w, "\t\treturn nil, fmt.Errorf(\"invalid selector for %v\")\n\t}\n\n", abiError.Signature)
+	fmt.Fprintf(w, "\te := new(%v)\n", errorType)
+	if len(fields) > 0 {
+		fmt.Fprintf(w, "\tif err := abi.UnmarshalValues(%v, data[4:]%v); err != nil {\n\t\treturn nil, err\n\t}\n\n", typeStrsLiteral(fields), strings.Join(refs, ""))
+	}
+	fmt.Fprintf(w, "\treturn e, nil\n}\n\n")
+	return nil
+}
+
+// decodeError generates DecodeError, which decodes revert data into
+// any of the contract custom errors.
+func (g *generator) decodeError(w *bytes.Buffer, errors []namedEntry[*abi.Error]) error {
+	if err := g.declare("DecodeError"); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "// DecodeError decodes revert data into one of the contract custom\n")
+	fmt.Fprintf(w, "// errors, or into an *abi.RevertReason for other revert data.\n")
+	fmt.Fprintf(w, "func DecodeError(data []byte) error {\n")
+	fmt.Fprintf(w, "\tif len(data) >= 4 {\n\t\tswitch {\n")
+	for _, abiError := range errors {
+		errorType := abiError.name + "Error"
+		fmt.Fprintf(w, "\t\tcase bytes.Equal(data[:4], %vSelector):\n", errorType)
+		fmt.Fprintf(w, "\t\t\tif e, err := Decode%v(data); err == nil {\n\t\t\t\treturn e\n\t\t\t}\n", errorType)
+	}
+	fmt.Fprintf(w, "\t\t}\n\t}\n\n")
+	fmt.Fprintf(w, "\treason, err := abi.DecodeRevert(data)\n")
+	fmt.Fprintf(w, "\tif err != nil {\n\t\treturn err\n\t}\n\n")
+	fmt.Fprintf(w, "\treturn reason\n}\n\n")
+	return nil
+}
+
+// fields converts arguments to Go fields, exported or not. hint names
+// the structs generated for tuples without internal type.
+func (g *generator) fields(args []abi.Argument, hint string, exported bool) []goField {
+	fields := make([]goField, len(args))
+	used := make(map[string]bool)
+	for i, arg := range args {
+		name := fieldName(arg.Name, i, exported)
+		for used[name] {
+			name += "_"
+		}
+		used[name] = true
+
+		t, _ := abi.ParseType(arg.Type)
+		goType, typeStr := g.goType(t, arg.Components, arg.InternalType, hint+exportedName(arg.Name, "Arg"+strconv.Itoa(i)))
+		fields[i] = goField{Name: name, ABIName: arg.Name, Type: goType, TypeStr: typeStr}
+	}
+
+	return fields
+}
+
+// goType returns the Go type and the ABI type string with named tuple
+// components of given type. Tuples are mapped to generated structs.
+func (g *generator) goType(t *abi.Type, components []abi.Argument, internalType string, hint string) (string, string) {
+	switch t.Kind {
+	case abi.ArrayKind:
+		elem, typeStr := g.goType(t.Elem, components, trimArraySuffix(internalType), hint)
+		return fmt.Sprintf("[%d]%v", t.Length, elem), fmt.Sprintf("%v[%d]", typeStr, t.Length)
+	case abi.SliceKind:
+		elem, typeStr := g.goType(t.Elem, components, trimArraySuffix(internalType), hint)
+		return "[]" + elem, typeStr + "[]"
+	case abi.TupleKind:
+		return g.tupleStruct(t, components, internalType, hint)
+	case abi.AddressKind:
+		g.imports["github.com/ethereum/go-ethereum/common"] = true
+		return "common.Address", t.String()
+	case abi.BoolKind:
+		return "bool", t.String()
+	case abi.StringKind:
+		return "string", t.String()
+	case abi.BytesKind:
+		return "[]byte", t.String()
+	case abi.FixedBytesKind:
+		return fmt.Sprintf("[%d]byte", t.Size), t.String()
+	case abi.IntKind, abi.UintKind:
+		switch t.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%v%d", t.Kind, t.Size), t.String()
+		}
+		g.imports["math/big"] = true
+		return "*big.Int", t.String()
+	default: // fixed and ufixed
+		return "*abi.Decimal", t.String()
+	}
+}
+
+// tupleStruct returns the generated struct of a tuple, generating it
+// on first use.
+func (g *generator) tupleStruct(t *abi.Type, components []abi.Argument, internalType string, hint string) (string, string) {
+	if len(components) != len(t.Components) {
+		components = make([]abi.Argument, len(t.Components))
+		for i, component := range t.Components {
+			components[i] = abi.Argument{Type: component.String()}
+		}
+	}
+
+	var fields []goField
+	componentStrs := make([]string, len(components))
+	used := make(map[string]bool)
+	for i, component := range components {
+		abiName := component.Name
+		if abiName == "" {
+			abiName = "arg" + strconv.Itoa(i)
+		}
+
+		name := fieldName(component.Name, i, true)
+		for used[name] {
+			name += "_"
+		}
+		used[name] = true
+
+		goType, typeStr := g.goType(t.Components[i], component.Components, component.InternalType, hint+exportedName(component.Name, "Arg"+strconv.Itoa(i)))
+		fields = append(fields, goField{Name: name, ABIName: abiName, Type: goType, TypeStr: typeStr})
+		componentStrs[i] = typeStr + " " + abiName
+	}
+
+	typeStr := "(" + strings.Join(componentStrs, ",") + ")"
+	if s, ok := g.structKeys[typeStr]; ok {
+		return s.Name, typeStr
+	}
+
+	name := hint
+	if structName, ok := strings.CutPrefix(internalType, "struct "); ok {
+		if i := strings.LastIndex(structName, "."); i != -1 {
+			structName = structName[i+1:]
+		}
+		name = exportedName(structName, hint)
+	}
+
+	base := name
+	for i := 0; g.names[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.names[name] = true
+
+	s := &goStruct{
+		Name:    name,
+		Comment: fmt.Sprintf("%v is the Go counterpart of the %v tuple.", name, t),
+		Fields:  fields,
+	}
+	g.structs = append(g.structs, s)
+	g.structKeys[typeStr] = s
+
+	return name, typeStr
+}
+
+// declare records top-level names, which must not be declared yet.
+func (g *generator) declare(names ...string) error {
+	for _, name := range names {
+		if g.names[name] {
+			return fmt.Errorf("duplicate Go name %v", name)
+		}
+		g.names[name] = true
+	}
+
+	return nil
+}
+
+// namedEntry is a function, event or custom error with its unique Go
+// name.
+type namedEntry[T any] struct {
+	name  string
+	entry T
+}
+
+// sortedEntries returns the functions, events or custom errors of a
+// contract sorted by signature, with unique Go names. Overloaded entries
+// get a numeric suffix.
+func sortedEntries[T any](entries map[string]T, fallback string, entryName func(T) string) []namedEntry[T] {
+	var sorted []namedEntry[T]
+	counts := make(map[string]int)
+	for _, signature := range sortedKeys(entries) {
+		entry := entries[signature]
+		name := exportedName(entryName(entry), fallback)
+		if count := counts[name]; count > 0 {
+			counts[name]++
+			name += strconv.Itoa(count - 1)
+		} else {
+			counts[name] = 1
+		}
+		sorted = append(sorted, namedEntry[T]{name: name, entry: entry})
+	}
+
+	return sorted
+}
+
+// sortedKeys returns the keys of given map, sorted.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// reservedNames are the identifiers used by the generated code that
+// parameters must not shadow.
+var reservedNames = map[string]bool{
+	"abi":      true,
+	"big":      true,
+	"bytes":    true,
+	"common":   true,
+	"fmt":      true,
+	"encoded":  true,
+	"err":      true,
+	"data":     true,
+	"out":      true,
+	"event":    true,
+	"e":        true,
+	"topics":   true,
+	"reason":   true,
+	"append":   true,
+	"len":      true,
+	"new":      true,
+	"string":   true,
+	"bool":     true,
+	"byte":     true,
+	"uint8":    true,
+	"uint16":   true,
+	"uint32":   true,
+	"uint64":   true,
+	"int8":     true,
+	"int16":    true,
+	"int32":    true,
+	"int64":    true,
+	"error":    true,
+	"nil":      true,
+	"true":     true,
+	"false":    true,
+	"iota":     true,
+	"any":      true,
+	"copy":     true,
+	"panic":    true,
+	"recover":  true,
+	"print":    true,
+	"println":  true,
+	"make":     true,
+	"cap":      true,
+	"close":    true,
+	"delete":   true,
+	"complex":  true,
+	"real":     true,
+	"imag":     true,
+	"clear":    true,
+	"min":      true,
+	"max":      true,
+	"rune":     true,
+	"uint":     true,
+	"int":      true,
+	"uintptr":  true,
+	"float32":  true,
+	"float64":  true,
+	"selector": true,
+}
+
+// fieldName returns the Go name of the i-th argument, exported or not.
+func fieldName(name string, i int, exported bool) string {
+	if exported {
+		return exportedName(name, "Arg"+strconv.Itoa(i))
+	}
+
+	name = identifier(name)
+	if name == "" {
+		return "arg" + strconv.Itoa(i)
+	}
+
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	name = string(runes)
+	if token.IsKeyword(name) || reservedNames[name] {
+		name += "_"
+	}
+
+	return name
+}
+
+// exportedName returns the exported Go name of a Solidity name, or
+// fallback if the name has no valid characters.
+func exportedName(name string, fallback string) string {
+	name = identifier(name)
+	if name == "" {
+		return fallback
+	}
+
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+
+	return string(runes)
+}
+
+// identifier removes the characters of a Solidity name that are not
+// valid in Go identifiers, and its leading underscores and digits.
+func identifier(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name)
+
+	return strings.TrimLeftFunc(name, func(r rune) bool {
+		return r == '_' || unicode.IsDigit(r)
+	})
+}
+
+// untagged returns given fields without abi tags.
+func untagged(fields []goField) []goField {
+	result := make([]goField, len(fields))
+	for i, field := range fields {
+		result[i] = goField{Name: field.Name, Type: field.Type, TypeStr: field.TypeStr}
+	}
+
+	return result
+}
+
+// typeStrsLiteral returns the Go literal of the type strings of
+// given fields.
+func typeStrsLiteral(fields []goField) string {
+	typeStrs := make([]string, len(fields))
+	for i, field := range fields {
+		typeStrs[i] = strconv.Quote(field.TypeStr)
+	}
+
+	return "[]string{" + strings.Join(typeStrs, ", ") + "}"
+}
+
+// trimArraySuffix removes the last array suffix of an internal type,
+// i.e. `struct Pool.Key[2][]` to `struct Pool.Key[2]`.
+func trimArraySuffix(internalType string) string {
+	if i := strings.LastIndex(internalType, "["); i != -1 && strings.HasSuffix(internalType, "]") {
+		return internalType[:i]
+	}
+
+	return internalType
+}
@@ -0,0 +1,5 @@
+// Package vault holds the bindings generated by abigen for a sample
+// vault contract, checked by the abigen tests.
+package vault
+
+//go:generate go run github.com/omnes-tech/abi/cmd/abigen --abi vault.abi.json --pkg vault --out vault.go
@@ -0,0 +1,310 @@
+// Code generated by github.com/omnes-tech/abi/cmd/abigen. DO NOT EDIT.
+
+package vault
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+// GetReservesOutput is the output of getReserves().
+type GetReservesOutput struct {
+	Reserve0 *big.Int
+	Reserve1 *big.Int
+	Arg2     uint32
+}
+
+// Details is the Go counterpart of the (uint160,uint48) tuple.
+type Details struct {
+	Amount *big.Int `abi:"amount"`
+	Nonce  *big.Int `abi:"nonce"`
+}
+
+// Permit is the Go counterpart of the (address,(uint160,uint48)) tuple.
+type Permit struct {
+	Owner   common.Address `abi:"owner"`
+	Details Details        `abi:"details"`
+}
+
+// DepositEvent is the Deposit(address,string,uint256,uint256) event.
+type DepositEvent struct {
+	To     common.Address
+	Memo   common.Hash
+	Amount *big.Int
+	Shares *big.Int
+}
+
+// Deposit0Event is the Deposit(uint256) event.
+type Deposit0Event struct {
+	Amount *big.Int
+}
+
+// BadError is the Bad() custom error.
+type BadError struct{}
+
+// Bad0Error is the Bad(uint256) custom error.
+type Bad0Error struct {
+	Code *big.Int
+}
+
+// InsufficientBalanceError is the InsufficientBalance(uint256,uint256) custom error.
+type InsufficientBalanceError struct {
+	Available *big.Int
+	Required  *big.Int
+}
+
+// UnauthorizedError is the Unauthorized() custom error.
+type UnauthorizedError struct{}
+
+// DepositSelector is the selector of deposit(address,uint256).
+var DepositSelector = common.Hex2Bytes("47e7ef24")
+
+// EncodeDeposit encodes a call to deposit(address,uint256).
+func EncodeDeposit(to common.Address, amount *big.Int) ([]byte, error) {
+	encoded, err := abi.MarshalValues([]string{"address", "uint256"}, to, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, DepositSelector...), encoded...), nil
+}
+
+// DecodeDepositOutput decodes the output of deposit(address,uint256).
+func DecodeDepositOutput(data []byte) (*big.Int, error) {
+	var out *big.Int
+	err := abi.UnmarshalValues([]string{"uint256"}, data, &out)
+	return out, err
+}
+
+// Deposit0Selector is the selector of deposit(uint256).
+var Deposit0Selector = common.Hex2Bytes("b6b55f25")
+
+// EncodeDeposit0 encodes a call to deposit(uint256).
+func EncodeDeposit0(amount *big.Int) ([]byte, error) {
+	encoded, err := abi.MarshalValues([]string{"uint256"}, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, Deposit0Selector...), encoded...), nil
+}
+
+// DecodeDeposit0Output decodes the output of deposit(uint256).
+func DecodeDeposit0Output(data []byte) (*big.Int, error) {
+	var out *big.Int
+	err := abi.UnmarshalValues([]string{"uint256"}, data, &out)
+	return out, err
+}
+
+// GetReservesSelector is the selector of getReserves().
+var GetReservesSelector = common.Hex2Bytes("0902f1ac")
+
+// EncodeGetReserves encodes a call to getReserves().
+func EncodeGetReserves() ([]byte, error) {
+	return append([]byte{}, GetReservesSelector...), nil
+}
+
+// DecodeGetReservesOutput decodes the output of getReserves().
+func DecodeGetReservesOutput(data []byte) (*GetReservesOutput, error) {
+	out := new(GetReservesOutput)
+	if err := abi.UnmarshalValues([]string{"uint112", "uint112", "uint32"}, data, &out.Reserve0, &out.Reserve1, &out.Arg2); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// PermitBatchSelector is the selector of permitBatch((address,(uint160,uint48))[],bytes).
+var PermitBatchSelector = common.Hex2Bytes("87369a44")
+
+// EncodePermitBatch encodes a call to permitBatch((address,(uint160,uint48))[],bytes).
+func EncodePermitBatch(permits []Permit, signature []byte) ([]byte, error) {
+	encoded, err := abi.MarshalValues([]string{"(address owner,(uint160 amount,uint48 nonce) details)[]", "bytes"}, permits, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, PermitBatchSelector...), encoded...), nil
+}
+
+// PositionSelector is the selector of position(bytes32).
+var PositionSelector = common.Hex2Bytes("957d1fe1")
+
+// EncodePosition encodes a call to position(bytes32).
+func EncodePosition(id [32]byte) ([]byte, error) {
+	encoded, err := abi.MarshalValues([]string{"bytes32"}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, PositionSelector...), encoded...), nil
+}
+
+// DecodePositionOutput decodes the output of position(bytes32).
+func DecodePositionOutput(data []byte) (Details, error) {
+	var out Details
+	err := abi.UnmarshalValues([]string{"(uint160 amount,uint48 nonce)"}, data, &out)
+	return out, err
+}
+
+// DepositTopic is the topic of the Deposit(address,string,uint256,uint256) event.
+var DepositTopic = common.Hex2Bytes("c9c6fab6ecbdd99f0fff2b919fb3ff1ef9ec3e382592cd5323cbb3571da624fc")
+
+// DecodeDeposit decodes a Deposit(address,string,uint256,uint256) event log.
+func DecodeDeposit(topics [][]byte, data []byte) (*DepositEvent, error) {
+	if len(topics) != 3 {
+		return nil, fmt.Errorf("topic count mismatch for Deposit(address,string,uint256,uint256): expected 3 topics, got %d", len(topics))
+	}
+
+	if !bytes.Equal(topics[0], DepositTopic) {
+		return nil, fmt.Errorf("topic0 mismatch for Deposit(address,string,uint256,uint256)")
+	}
+
+	event := new(DepositEvent)
+	if err := abi.UnmarshalValues([]string{"address"}, topics[1], &event.To); err != nil {
+		return nil, err
+	}
+	event.Memo = common.BytesToHash(topics[2])
+	if err := abi.UnmarshalValues([]string{"uint256", "uint256"}, data, &event.Amount, &event.Shares); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// Deposit0Topic is the topic of the Deposit(uint256) event.
+var Deposit0Topic = common.Hex2Bytes("4d6ce1e535dbade1c23defba91e23b8f791ce5edc0cc320257a2b364e4e38426")
+
+// DecodeDeposit0 decodes a Deposit(uint256) event log.
+func DecodeDeposit0(topics [][]byte, data []byte) (*Deposit0Event, error) {
+	if len(topics) != 1 {
+		return nil, fmt.Errorf("topic count mismatch for Deposit(uint256): expected 1 topics, got %d", len(topics))
+	}
+
+	if !bytes.Equal(topics[0], Deposit0Topic) {
+		return nil, fmt.Errorf("topic0 mismatch for Deposit(uint256)")
+	}
+
+	event := new(Deposit0Event)
+	if err := abi.UnmarshalValues([]string{"uint256"}, data, &event.Amount); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// BadErrorSelector is the selector of the Bad() custom error.
+var BadErrorSelector = common.Hex2Bytes("e143a034")
+
+// Error implements the error interface.
+func (e *BadError) Error() string {
+	return "Bad()"
+}
+
+// DecodeBadError decodes Bad() revert data.
+func DecodeBadError(data []byte) (*BadError, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], BadErrorSelector) {
+		return nil, fmt.Errorf("invalid selector for Bad()")
+	}
+
+	e := new(BadError)
+	return e, nil
+}
+
+// Bad0ErrorSelector is the selector of the Bad(uint256) custom error.
+var Bad0ErrorSelector = common.Hex2Bytes("a2f43130")
+
+// Error implements the error interface.
+func (e *Bad0Error) Error() string {
+	return fmt.Sprintf("Bad(code: %v)", e.Code)
+}
+
+// DecodeBad0Error decodes Bad(uint256) revert data.
+func DecodeBad0Error(data []byte) (*Bad0Error, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], Bad0ErrorSelector) {
+		return nil, fmt.Errorf("invalid selector for Bad(uint256)")
+	}
+
+	e := new(Bad0Error)
+	if err := abi.UnmarshalValues([]string{"uint256"}, data[4:], &e.Code); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// InsufficientBalanceErrorSelector is the selector of the InsufficientBalance(uint256,uint256) custom error.
+var InsufficientBalanceErrorSelector = common.Hex2Bytes("cf479181")
+
+// Error implements the error interface.
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("InsufficientBalance(available: %v, required: %v)", e.Available, e.Required)
+}
+
+// DecodeInsufficientBalanceError decodes InsufficientBalance(uint256,uint256) revert data.
+func DecodeInsufficientBalanceError(data []byte) (*InsufficientBalanceError, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], InsufficientBalanceErrorSelector) {
+		return nil, fmt.Errorf("invalid selector for InsufficientBalance(uint256,uint256)")
+	}
+
+	e := new(InsufficientBalanceError)
+	if err := abi.UnmarshalValues([]string{"uint256", "uint256"}, data[4:], &e.Available, &e.Required); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// UnauthorizedErrorSelector is the selector of the Unauthorized() custom error.
+var UnauthorizedErrorSelector = common.Hex2Bytes("82b42900")
+
+// Error implements the error interface.
+func (e *UnauthorizedError) Error() string {
+	return "Unauthorized()"
+}
+
+// DecodeUnauthorizedError decodes Unauthorized() revert data.
+func DecodeUnauthorizedError(data []byte) (*UnauthorizedError, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], UnauthorizedErrorSelector) {
+		return nil, fmt.Errorf("invalid selector for Unauthorized()")
+	}
+
+	e := new(UnauthorizedError)
+	return e, nil
+}
+
+// DecodeError decodes revert data into one of the contract custom
+// errors, or into an *abi.RevertReason for other revert data.
+func DecodeError(data []byte) error {
+	if len(data) >= 4 {
+		switch {
+		case bytes.Equal(data[:4], BadErrorSelector):
+			if e, err := DecodeBadError(data); err == nil {
+				return e
+			}
+		case bytes.Equal(data[:4], Bad0ErrorSelector):
+			if e, err := DecodeBad0Error(data); err == nil {
+				return e
+			}
+		case bytes.Equal(data[:4], InsufficientBalanceErrorSelector):
+			if e, err := DecodeInsufficientBalanceError(data); err == nil {
+				return e
+			}
+		case bytes.Equal(data[:4], UnauthorizedErrorSelector):
+			if e, err := DecodeUnauthorizedError(data); err == nil {
+				return e
+			}
+		}
+	}
+
+	reason, err := abi.DecodeRevert(data)
+	if err != nil {
+		return err
+	}
+
+	return reason
+}
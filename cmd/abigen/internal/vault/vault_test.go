@@ -0,0 +1,85 @@
+package vault_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+	"github.com/omnes-tech/abi/cmd/abigen/internal/vault"
+)
+
+func ExampleEncodePermitBatch() {
+	permits := []vault.Permit{{
+		Owner:   common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"),
+		Details: vault.Details{Amount: big.NewInt(100), Nonce: big.NewInt(1)},
+	}}
+
+	encoded, err := vault.EncodePermitBatch(permits, []byte{0xde, 0xad})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	decoded, err := abi.DecodeWithSignature("permitBatch((address,(uint160,uint48))[],bytes)", encoded)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(decoded)
+
+	// Output: [[[0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 [100 1]]] [222 173]]
+}
+
+func ExampleDecodeGetReservesOutput() {
+	data, err := abi.EncodeReturn("getReserves()(uint112,uint112,uint32)", big.NewInt(1000), big.NewInt(2000), big.NewInt(1700000000))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	reserves, err := vault.DecodeGetReservesOutput(data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(reserves.Reserve0, reserves.Reserve1, reserves.Arg2)
+
+	// Output: 1000 2000 1700000000
+}
+
+func ExampleDecodeDeposit() {
+	to := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	topics := [][]byte{
+		vault.DepositTopic,
+		common.LeftPadBytes(to.Bytes(), 32),
+		abi.EncodeEventTopic("gm"),
+	}
+	data, err := abi.Encode([]string{"uint256", "uint256"}, big.NewInt(1000), big.NewInt(999))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	event, err := vault.DecodeDeposit(topics, data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(event.To, event.Memo.Hex() == common.BytesToHash(abi.EncodeEventTopic("gm")).Hex(), event.Amount, event.Shares)
+
+	// Output: 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 true 1000 999
+}
+
+func ExampleDecodeError() {
+	data, err := abi.EncodeWithSignature("InsufficientBalance(uint256,uint256)", big.NewInt(1), big.NewInt(2))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(vault.DecodeError(data))
+	fmt.Println(vault.DecodeError(vault.UnauthorizedErrorSelector))
+	fmt.Println(vault.DecodeError(vault.BadErrorSelector))
+
+	// Output:
+	// InsufficientBalance(available: 1, required: 2)
+	// Unauthorized()
+	// Bad()
+}
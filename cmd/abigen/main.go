@@ -0,0 +1,67 @@
+// Command abigen generates typed Go bindings for a contract JSON ABI,
+// built on the abi package only.
+//
+// Usage:
+//
+//	abigen --abi <file> --pkg <package> [--out <file>]
+//
+// For each function, it generates `Encode<Function>` and, when the
+// function has outputs, `Decode<Function>Output`. For each event, it
+// generates `Decode<Event>` and an `<Event>Event` struct. For each
+// custom error, it generates an `<Error>Error` type implementing the
+// error interface, `Decode<Error>Error`, and a `DecodeError` function
+// for any revert data. Overloaded functions, events and errors get a
+// numeric suffix, i.e. `EncodeDeposit0`. Tuples become Go structs,
+// named after their Solidity struct when the ABI provides internal
+// types.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/omnes-tech/abi"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "abigen:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the generator with given args, writing the generated code
+// to w unless an output file is given.
+func run(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("abigen", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	abiPath := flags.String("abi", "", "path of the contract JSON ABI or compiler artifact")
+	pkg := flags.String("pkg", "", "package name of the generated code")
+	out := flags.String("out", "", "path of the generated file, standard output if empty")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%v\nusage: abigen --abi <file> --pkg <package> [--out <file>]", err)
+	}
+
+	if *abiPath == "" || *pkg == "" {
+		return fmt.Errorf("usage: abigen --abi <file> --pkg <package> [--out <file>]")
+	}
+
+	contract, err := abi.LoadContractFile(*abiPath)
+	if err != nil {
+		return err
+	}
+
+	source, err := generate(contract, *pkg)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = w.Write(source)
+		return err
+	}
+
+	return os.WriteFile(*out, source, 0o644)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/omnes-tech/abi"
+)
+
+func Example() {
+	var generated bytes.Buffer
+	if err := run([]string{"--abi", "internal/vault/vault.abi.json", "--pkg", "vault"}, &generated); err != nil {
+		fmt.Println(err)
+	}
+
+	checkedIn, err := os.ReadFile("internal/vault/vault.go")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// regenerate with `go generate ./cmd/abigen/...` when this fails
+	fmt.Println(bytes.Equal(generated.Bytes(), checkedIn))
+
+	// Output: true
+}
+
+func Example_duplicateName() {
+	contract, err := abi.LoadHumanReadable(
+		"function deposit(uint256 amount)",
+		"function deposit(address to)",
+		"function deposit0()",
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	_, err = generate(contract, "vault")
+	fmt.Println(err)
+
+	// Output: duplicate Go name Deposit0Selector
+}
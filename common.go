@@ -2,28 +2,21 @@ package abi
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // IsDynamic checks whether given type string is a dynamic,
-// i.e. if it is either a string, bytes, or an array.
+// i.e. if it is either a string, bytes, a dynamic array or
+// contains a dynamic type. isTuple is kept for backwards
+// compatibility, the type string is parsed to find it out.
+// Returns false for invalid type strings.
 func IsDynamic(typeStr string, isTuple bool) bool {
-
-	openBracketsIndex := strings.Index(typeStr, "[")
-	closeBracketsIndex := strings.Index(typeStr, "]")
-
-	if openBracketsIndex == -1 {
-		if strings.Contains(typeStr, "string") ||
-			typeStr == "bytes" ||
-			(isTuple && (strings.Contains(typeStr, "bytes,") || strings.Contains(typeStr, "bytes)"))) {
-			return true
-		}
-	} else if closeBracketsIndex == openBracketsIndex+1 {
-		return true
+	t, err := ParseType(typeStr)
+	if err != nil {
+		return false
 	}
 
-	return false
+	return t.IsDynamic()
 }
 
 // IsArray checks whether given type string is an array.
@@ -32,87 +25,119 @@ func IsDynamic(typeStr string, isTuple bool) bool {
 // be 0 wheter an error occured, it is not an array,
 // or it is an unbounded array (i.e. `uint256[]`).
 func IsArray(typeStr string) (bool, int, error) {
-	if strings.Count(typeStr, "[") != strings.Count(typeStr, "]") {
-		return false, 0, fmt.Errorf("invalid array definition")
+	t, err := ParseType(typeStr)
+	if err != nil {
+		return false, 0, err
 	}
 
-	if strings.Count(typeStr, "[") > 0 {
-		openBracketIndex := strings.LastIndex(typeStr, "[")
-		closeBracketIndex := strings.LastIndex(typeStr, "]")
-		closeParenthesisIndex := strings.LastIndex(typeStr, ")")
-
-		if openBracketIndex < closeParenthesisIndex || closeBracketIndex < closeParenthesisIndex {
-			return false, 0, nil
-		}
-
-		var arraySize int
-		var err error
-		if closeBracketIndex > openBracketIndex+1 {
-			arraySize, err = strconv.Atoi(typeStr[openBracketIndex+1 : closeBracketIndex])
-			if err != nil {
-				return false, 0, fmt.Errorf("invalid array definition")
-			}
-		}
-
-		return true, arraySize, nil
+	switch t.Kind {
+	case ArrayKind:
+		return true, t.Length, nil
+	case SliceKind:
+		return true, 0, nil
+	default:
+		return false, 0, nil
 	}
-
-	return false, 0, nil
 }
 
-// IsTuple checks whether given type string is a tuple (i.e. `(uint256,bytes,address)`).
+// IsTuple checks whether given type string is a tuple (i.e. `(uint256,bytes,address)`)
+// or an array of tuples (i.e. `(uint256,bytes,address)[]`).
 // Also returns the array of type strings in the tuple (i.e. [uint256,bytes,address]).
 func IsTuple(typeStr string) (bool, []string, error) {
-	if strings.Count(typeStr, "(") != strings.Count(typeStr, ")") {
-		return false, nil, fmt.Errorf("invalid tuple definition")
-	}
-
-	if strings.Count(typeStr, "(") > 0 {
-		openParenthesisIndex := strings.Index(typeStr, "(")
-		closeParenthesisIndex := strings.LastIndex(typeStr, ")")
-		innerCloseParenthesisIndex := strings.LastIndex(typeStr[:closeParenthesisIndex], ")")
-		innerCloseBracketsIndex := strings.LastIndex(typeStr[:closeParenthesisIndex], "]")
-
-		var splitTypes []string
-		if innerCloseParenthesisIndex != -1 &&
-			innerCloseBracketsIndex != -1 {
-			innerOpenParenthesisIndex := strings.Index(typeStr[openParenthesisIndex+1:closeParenthesisIndex], "(") + len(typeStr[:openParenthesisIndex]) + 1
-			if innerCloseParenthesisIndex > innerCloseBracketsIndex {
-				splitTypes = strings.Split(typeStr[openParenthesisIndex+1:innerOpenParenthesisIndex], ",")
-				splitTypes = append(splitTypes, typeStr[innerOpenParenthesisIndex:innerCloseParenthesisIndex+1])
-				splitTypes = append(splitTypes, strings.Split(typeStr[innerCloseParenthesisIndex+2:closeParenthesisIndex], ",")...)
-			} else {
-				splitTypes = strings.Split(typeStr[openParenthesisIndex+1:innerOpenParenthesisIndex], ",")
-				splitTypes = append(splitTypes, typeStr[innerOpenParenthesisIndex:innerCloseBracketsIndex+1])
-				splitTypes = append(splitTypes, strings.Split(typeStr[innerCloseBracketsIndex+2:closeParenthesisIndex], ",")...)
-			}
-		} else {
-			splitTypes = SplitParams(typeStr[openParenthesisIndex+1 : closeParenthesisIndex])
-		}
+	t, err := ParseType(typeStr)
+	if err != nil {
+		return false, nil, err
+	}
 
-		splitTypes = cleanSplitTypes(splitTypes)
+	for t.Kind == ArrayKind || t.Kind == SliceKind {
+		t = t.Elem
+	}
+
+	if t.Kind != TupleKind {
+		return false, nil, nil
+	}
 
-		return true, splitTypes, nil
+	var splitTypes []string
+	for _, component := range t.Components {
+		splitTypes = append(splitTypes, component.String())
 	}
 
-	return false, nil, nil
+	return true, splitTypes, nil
 }
 
 // GetSigTypes gets the input parameters type from given function
-// signature.
+// signature. Output parameters, if any (i.e. `balanceOf(address)(uint256)`),
+// are ignored.
 // Calls splitParams function.
 func GetSigTypes(funcSig string) ([]string, error) {
+	_, inputs, _, err := splitSignature(funcSig)
+	if err != nil {
+		return []string{}, err
+	}
+
+	return SplitParams(inputs), nil
+}
+
+// GetSigOutputTypes gets the output parameters type from given function
+// signature with outputs, i.e. `balanceOf(address)(uint256)`. Returns
+// no types when the signature has no outputs.
+// Calls splitParams function.
+func GetSigOutputTypes(funcSig string) ([]string, error) {
+	_, _, outputs, err := splitSignature(funcSig)
+	if err != nil {
+		return []string{}, err
+	}
+
+	return SplitParams(outputs), nil
+}
+
+// splitSignature splits a function signature with optional outputs
+// into the signature without outputs, the input parameters string and
+// the output parameters string, i.e. `getReserves()(uint112,uint112,uint32)`
+// into `getReserves()`, an empty string and `uint112,uint112,uint32`.
+func splitSignature(funcSig string) (string, string, string, error) {
 	openParIndex := strings.Index(funcSig, "(")
 	if openParIndex == -1 {
-		return []string{}, fmt.Errorf("no opening parenthesis found in function signature")
+		return "", "", "", fmt.Errorf("no opening parenthesis found in function signature")
 	}
 
-	closeParIndex := strings.LastIndex(funcSig, ")")
+	closeParIndex := matchingParenthesis(funcSig, openParIndex)
 	if closeParIndex == -1 {
-		return []string{}, fmt.Errorf("no closing parenthesis found in function signature")
+		return "", "", "", fmt.Errorf("no closing parenthesis found in function signature")
 	}
 
-	return SplitParams(funcSig[openParIndex+1 : closeParIndex]), nil
+	inputSig := funcSig[:closeParIndex+1]
+	inputs := funcSig[openParIndex+1 : closeParIndex]
+
+	rest := funcSig[closeParIndex+1:]
+	if rest == "" {
+		return inputSig, inputs, "", nil
+	}
+
+	if rest[0] != '(' || matchingParenthesis(rest, 0) != len(rest)-1 {
+		return "", "", "", fmt.Errorf("invalid output parameters in function signature: %q", rest)
+	}
+
+	return inputSig, inputs, rest[1 : len(rest)-1], nil
+}
+
+// matchingParenthesis returns the index of the parenthesis closing
+// the one at given index, or -1 if there is none.
+func matchingParenthesis(s string, openIndex int) int {
+	depth := 0
+	for i := openIndex; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
 }
 
 // SplitParams splits parameters type from given type string
@@ -144,13 +169,3 @@ func SplitParams(typesStr string) []string {
 
 	return result
 }
-
-func cleanSplitTypes(splitTypes []string) []string {
-	var result []string
-	for _, typeStr := range splitTypes {
-		if typeStr != "" {
-			result = append(result, typeStr)
-		}
-	}
-	return result
-}
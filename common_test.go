@@ -18,6 +18,23 @@ func ExampleGetSigTypes() {
 	// Output: [(address,uint256,bytes)[] uint256 string bool]
 }
 
+func ExampleGetSigOutputTypes() {
+	signature := "getReserves()(uint112,uint112,uint32)"
+	outputTypes, err := abi.GetSigOutputTypes(signature)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	inputTypes, err := abi.GetSigTypes(signature)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(outputTypes, len(inputTypes))
+
+	// Output: [uint112 uint112 uint32] 0
+}
+
 func ExampleSplitParams() {
 	typesStr := "(address,uint256,bytes)[],uint256,string,bool,address"
 	splittedTypes := abi.SplitParams(typesStr)
@@ -59,3 +76,15 @@ func ExampleIsArray() {
 
 	// Output: true 0
 }
+
+func ExampleIsTuple_nested() {
+	typeStr := "(uint8,(address,bytes)[],(uint256,bool))"
+	isTuple, types, err := abi.IsTuple(typeStr)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(isTuple, types)
+
+	// Output: true [uint8 (address,bytes)[] (uint256,bool)]
+}
@@ -0,0 +1,168 @@
+package abi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+// conformanceVector is a test vector of testdata/conformance, with
+// values following the canonical JSON mapping of DecodeToJSON.
+type conformanceVector struct {
+	Name         string          `json:"name"`
+	Source       string          `json:"source"`
+	Signature    string          `json:"signature"`
+	Selector     string          `json:"selector"`
+	Types        []string        `json:"types"`
+	Values       json.RawMessage `json:"values"`
+	Encoded      string          `json:"encoded"`
+	Packed       *string         `json:"packed"`
+	DecodePacked bool            `json:"decodePacked"`
+}
+
+// TestConformance runs the vectors of the Solidity ABI specification,
+// of the ethabi test suite and of corner cases against Encode, Decode,
+// EncodePacked and DecodePacked, and against EncodeWithSignature and
+// DecodeWithSignature for vectors with a signature.
+func TestConformance(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "conformance", "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no conformance vectors found: %v", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vectors []conformanceVector
+		if err := json.Unmarshal(data, &vectors); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+
+		for _, v := range vectors {
+			t.Run(filepath.Base(file)+"/"+v.Name, func(t *testing.T) {
+				testConformanceVector(t, v)
+			})
+		}
+	}
+}
+
+func testConformanceVector(t *testing.T, v conformanceVector) {
+	encoded := common.FromHex(v.Encoded)
+
+	values, err := abi.ParseValuesJSON(v.Types, v.Values)
+	if err != nil {
+		t.Fatalf("ParseValuesJSON() error: %v", err)
+	}
+
+	got, err := abi.Encode(v.Types, values...)
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+	if !bytes.Equal(got, encoded) {
+		t.Errorf("Encode() mismatch:\n got 0x%x\nwant 0x%x", got, encoded)
+	}
+
+	if _, err := abi.Decode(v.Types, encoded); err != nil {
+		t.Errorf("Decode() error: %v", err)
+	}
+
+	if _, err := abi.DecodeStrict(v.Types, encoded); err != nil {
+		t.Errorf("DecodeStrict() error: %v", err)
+	}
+
+	decoded, err := abi.DecodeTyped(v.Types, encoded)
+	if err != nil {
+		t.Fatalf("DecodeTyped() error: %v", err)
+	}
+	if got, err := abi.Encode(v.Types, decoded...); err != nil || !bytes.Equal(got, encoded) {
+		t.Errorf("Encode() of decoded values mismatch: %v\n got 0x%x\nwant 0x%x", err, got, encoded)
+	}
+
+	jsonValues, err := abi.DecodeToJSON(v.Types, encoded)
+	if err != nil {
+		t.Fatalf("DecodeToJSON() error: %v", err)
+	}
+	var want bytes.Buffer
+	if err := json.Compact(&want, v.Values); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(jsonValues, want.Bytes()) {
+		t.Errorf("DecodeToJSON() mismatch:\n got %s\nwant %s", jsonValues, want.Bytes())
+	}
+
+	if v.Signature != "" {
+		testConformanceSignature(t, v, values)
+	}
+
+	if v.Packed == nil {
+		return
+	}
+	packed := common.FromHex(*v.Packed)
+
+	got, err = abi.EncodePacked(v.Types, values...)
+	if err != nil {
+		t.Fatalf("EncodePacked() error: %v", err)
+	}
+	if !bytes.Equal(got, packed) {
+		t.Errorf("EncodePacked() mismatch:\n got 0x%x\nwant 0x%x", got, packed)
+	}
+
+	if !v.DecodePacked {
+		return
+	}
+
+	unpacked, err := abi.DecodePacked(v.Types, packed)
+	if err != nil {
+		t.Fatalf("DecodePacked() error: %v", err)
+	}
+	for i, typeStr := range v.Types {
+		unpacked[i] = packedValue(typeStr, unpacked[i])
+	}
+	if got, err := abi.EncodePacked(v.Types, unpacked...); err != nil || !bytes.Equal(got, packed) {
+		t.Errorf("EncodePacked() of unpacked values mismatch: %v\n got 0x%x\nwant 0x%x", err, got, packed)
+	}
+}
+
+func testConformanceSignature(t *testing.T, v conformanceVector, values []any) {
+	selector := abi.EncodeSignature(v.Signature)
+	if v.Selector != "" && !bytes.Equal(selector, common.FromHex(v.Selector)) {
+		t.Errorf("EncodeSignature() mismatch: got 0x%x, want %v", selector, v.Selector)
+	}
+
+	calldata := append(selector, common.FromHex(v.Encoded)...)
+	got, err := abi.EncodeWithSignature(v.Signature, values...)
+	if err != nil {
+		t.Fatalf("EncodeWithSignature() error: %v", err)
+	}
+	if !bytes.Equal(got, calldata) {
+		t.Errorf("EncodeWithSignature() mismatch:\n got 0x%x\nwant 0x%x", got, calldata)
+	}
+
+	if _, err := abi.DecodeWithSignature(v.Signature, calldata); err != nil {
+		t.Errorf("DecodeWithSignature() error: %v", err)
+	}
+}
+
+// packedValue converts a value decoded by DecodePacked, which returns
+// addresses and bytes as hex strings, to a value accepted by
+// EncodePacked.
+func packedValue(typeStr string, value any) any {
+	s, ok := value.(string)
+	switch {
+	case ok && typeStr == "address":
+		return common.HexToAddress(s)
+	case ok && strings.HasPrefix(typeStr, "bytes"):
+		return common.FromHex(s)
+	default:
+		return value
+	}
+}
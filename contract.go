@@ -0,0 +1,364 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Argument is a parameter of a function, event or error.
+type Argument struct {
+	Name         string
+	Type         string     // canonical type string with flattened tuples, i.e. `(address,uint256)[]`
+	InternalType string     // type name given by the compiler, i.e. `struct Pool.Key[]`
+	Indexed      bool       // whether an event parameter is indexed
+	Components   []Argument // named components of tuples and arrays of tuples
+}
+
+// Method is a function, constructor, fallback or receive entry
+// of a contract ABI.
+type Method struct {
+	Name            string
+	Type            string // function, constructor, fallback or receive
+	Inputs          []Argument
+	Outputs         []Argument
+	StateMutability string
+	Signature       string // canonical signature, i.e. `transfer(address,uint256)`
+	Selector        []byte
+}
+
+// Event is an event entry of a contract ABI.
+type Event struct {
+	Name      string
+	Inputs    []Argument
+	Anonymous bool
+	Signature string // canonical signature, i.e. `Transfer(address,address,uint256)`
+	Topic     []byte // keccak256 hash of the signature
+}
+
+// Error is a custom error entry of a contract ABI.
+type Error struct {
+	Name      string
+	Inputs    []Argument
+	Signature string // canonical signature, i.e. `InsufficientBalance(uint256,uint256)`
+	Selector  []byte
+}
+
+// Contract is a contract ABI loaded from its JSON definition.
+// Functions, events and errors are keyed by their canonical signature.
+type Contract struct {
+	Constructor *Method
+	Fallback    *Method
+	Receive     *Method
+	Functions   map[string]*Method
+	Events      map[string]*Event
+	Errors      map[string]*Error
+}
+
+// jsonArgument is an argument as found in a JSON ABI.
+type jsonArgument struct {
+	Name         string         `json:"name"`
+	Type         string         `json:"type"`
+	InternalType string         `json:"internalType,omitempty"`
+	Indexed      bool           `json:"indexed,omitempty"`
+	Components   []jsonArgument `json:"components,omitempty"`
+}
+
+// jsonEntry is an entry as found in a JSON ABI.
+type jsonEntry struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []jsonArgument `json:"inputs"`
+	Outputs         []jsonArgument `json:"outputs"`
+	StateMutability string         `json:"stateMutability"`
+	Anonymous       bool           `json:"anonymous"`
+	Constant        bool           `json:"constant"` // legacy
+	Payable         bool           `json:"payable"`  // legacy
+}
+
+// LoadContract loads a contract from its JSON ABI. Accepts either the
+// ABI array emitted by solc or an artifact holding it in an `abi` field,
+// such as Foundry `out/*.json` and Hardhat artifacts.
+func LoadContract(jsonData []byte) (*Contract, error) {
+	jsonData = bytes.TrimSpace(jsonData)
+
+	var entries []jsonEntry
+	if len(jsonData) > 0 && jsonData[0] == '{' {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(jsonData, &artifact); err != nil {
+			return nil, fmt.Errorf("error parsing contract artifact: %v", err)
+		}
+		if artifact.ABI == nil {
+			return nil, fmt.Errorf("no abi field found in contract artifact")
+		}
+		jsonData = artifact.ABI
+	}
+
+	if err := json.Unmarshal(jsonData, &entries); err != nil {
+		return nil, fmt.Errorf("error parsing contract ABI: %v", err)
+	}
+
+	contract := &Contract{
+		Functions: make(map[string]*Method),
+		Events:    make(map[string]*Event),
+		Errors:    make(map[string]*Error),
+	}
+	for _, entry := range entries {
+		if err := contract.addEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return contract, nil
+}
+
+// LoadContractFile loads a contract from a JSON ABI or artifact file.
+// Calls LoadContract function.
+func LoadContractFile(path string) (*Contract, error) {
+	jsonData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadContract(jsonData)
+}
+
+// addEntry adds a JSON ABI entry to the contract.
+func (c *Contract) addEntry(entry jsonEntry) error {
+	inputs, err := toArguments(entry.Inputs)
+	if err != nil {
+		return fmt.Errorf("invalid inputs for %v %v: %v", entry.Type, entry.Name, err)
+	}
+
+	switch entry.Type {
+	case "function", "", "constructor", "fallback", "receive":
+		outputs, err := toArguments(entry.Outputs)
+		if err != nil {
+			return fmt.Errorf("invalid outputs for %v %v: %v", entry.Type, entry.Name, err)
+		}
+
+		method := &Method{
+			Name:            entry.Name,
+			Type:            entry.Type,
+			Inputs:          inputs,
+			Outputs:         outputs,
+			StateMutability: entry.StateMutability,
+		}
+		if method.Type == "" {
+			method.Type = "function"
+		}
+		if method.StateMutability == "" {
+			method.StateMutability = legacyStateMutability(entry)
+		}
+
+		switch method.Type {
+		case "constructor":
+			c.Constructor = method
+		case "fallback":
+			c.Fallback = method
+		case "receive":
+			c.Receive = method
+		default:
+			method.Signature = buildSignature(method.Name, inputs)
+			method.Selector = EncodeSignature(method.Signature)
+			c.Functions[method.Signature] = method
+		}
+	case "event":
+		event := &Event{
+			Name:      entry.Name,
+			Inputs:    inputs,
+			Anonymous: entry.Anonymous,
+			Signature: buildSignature(entry.Name, inputs),
+		}
+		event.Topic = crypto.Keccak256([]byte(event.Signature))
+		c.Events[event.Signature] = event
+	case "error":
+		abiError := &Error{
+			Name:      entry.Name,
+			Inputs:    inputs,
+			Signature: buildSignature(entry.Name, inputs),
+		}
+		abiError.Selector = EncodeSignature(abiError.Signature)
+		c.Errors[abiError.Signature] = abiError
+	default:
+		return fmt.Errorf("invalid ABI entry type: %v", entry.Type)
+	}
+
+	return nil
+}
+
+// Function returns the function with given name or canonical
+// signature. Overloaded functions must be referred to by signature.
+func (c *Contract) Function(name string) (*Method, error) {
+	return lookupByName(c.Functions, "function", name, func(method *Method) string { return method.Name })
+}
+
+// Event returns the event with given name or canonical signature.
+// Overloaded events must be referred to by signature.
+func (c *Contract) Event(name string) (*Event, error) {
+	return lookupByName(c.Events, "event", name, func(event *Event) string { return event.Name })
+}
+
+// Error returns the custom error with given name or canonical
+// signature. Overloaded errors must be referred to by signature.
+func (c *Contract) Error(name string) (*Error, error) {
+	return lookupByName(c.Errors, "error", name, func(abiError *Error) string { return abiError.Name })
+}
+
+// lookupByName returns the entry with given name or canonical
+// signature from entries keyed by signature. Returns an error when
+// the name is overloaded.
+func lookupByName[T any](entries map[string]T, kind string, name string, entryName func(T) string) (T, error) {
+	var none T
+	if strings.Contains(name, "(") {
+		entry, ok := entries[name]
+		if !ok {
+			return none, fmt.Errorf("%v not found: %v", kind, name)
+		}
+		return entry, nil
+	}
+
+	var signatures []string
+	for signature, entry := range entries {
+		if entryName(entry) == name {
+			signatures = append(signatures, signature)
+		}
+	}
+
+	switch len(signatures) {
+	case 0:
+		return none, fmt.Errorf("%v not found: %v", kind, name)
+	case 1:
+		return entries[signatures[0]], nil
+	default:
+		sort.Strings(signatures)
+		return none, fmt.Errorf("%v %v is overloaded, use one of the signatures: %v", kind, name, signatures)
+	}
+}
+
+// EncodeCall encodes a call to given function with given arguments.
+// Calls EncodeWithSelector function.
+func (c *Contract) EncodeCall(name string, args ...any) ([]byte, error) {
+	method, err := c.Function(name)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return EncodeWithSelector(method.Selector, method.InputTypes(), args...)
+}
+
+// DecodeCall decodes calldata, finding the called function by its selector.
+func (c *Contract) DecodeCall(data []byte) (*Method, []any, error) {
+	if len(data) < 4 {
+		return nil, nil, fmt.Errorf("calldata is too short: %d bytes", len(data))
+	}
+
+	for _, method := range c.Functions {
+		if bytes.Equal(method.Selector, data[:4]) {
+			decoded, err := Decode(method.InputTypes(), data[4:])
+			if err != nil {
+				return nil, nil, err
+			}
+			return method, decoded, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no function found for selector 0x%x", data[:4])
+}
+
+// DecodeOutput decodes the return data of given function.
+func (c *Contract) DecodeOutput(name string, data []byte) ([]any, error) {
+	method, err := c.Function(name)
+	if err != nil {
+		return []any{}, err
+	}
+
+	return Decode(method.OutputTypes(), data)
+}
+
+// InputTypes returns the type strings of the method inputs.
+func (m *Method) InputTypes() []string {
+	return argumentTypes(m.Inputs)
+}
+
+// OutputTypes returns the type strings of the method outputs.
+func (m *Method) OutputTypes() []string {
+	return argumentTypes(m.Outputs)
+}
+
+// InputTypes returns the type strings of the event inputs.
+func (e *Event) InputTypes() []string {
+	return argumentTypes(e.Inputs)
+}
+
+// InputTypes returns the type strings of the error inputs.
+func (e *Error) InputTypes() []string {
+	return argumentTypes(e.Inputs)
+}
+
+// toArguments converts JSON ABI arguments, flattening tuple
+// components into tuple type strings.
+func toArguments(jsonArgs []jsonArgument) ([]Argument, error) {
+	var args []Argument
+	for _, jsonArg := range jsonArgs {
+		components, err := toArguments(jsonArg.Components)
+		if err != nil {
+			return nil, err
+		}
+
+		typeStr := jsonArg.Type
+		if strings.HasPrefix(typeStr, "tuple") {
+			typeStr = "(" + strings.Join(argumentTypes(components), ",") + ")" + typeStr[len("tuple"):]
+		}
+
+		t, err := ParseType(typeStr)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, Argument{
+			Name:         jsonArg.Name,
+			Type:         t.String(),
+			InternalType: jsonArg.InternalType,
+			Indexed:      jsonArg.Indexed,
+			Components:   components,
+		})
+	}
+
+	return args, nil
+}
+
+// argumentTypes returns the type strings of given arguments.
+func argumentTypes(args []Argument) []string {
+	typeStrs := make([]string, len(args))
+	for i, arg := range args {
+		typeStrs[i] = arg.Type
+	}
+
+	return typeStrs
+}
+
+// buildSignature builds the canonical signature for given name and arguments.
+func buildSignature(name string, args []Argument) string {
+	return name + "(" + strings.Join(argumentTypes(args), ",") + ")"
+}
+
+// legacyStateMutability derives the state mutability from the legacy
+// `constant` and `payable` fields.
+func legacyStateMutability(entry jsonEntry) string {
+	switch {
+	case entry.Constant:
+		return "view"
+	case entry.Payable:
+		return "payable"
+	default:
+		return "nonpayable"
+	}
+}
@@ -0,0 +1,142 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+const erc20Artifact = `{
+	"abi": [
+		{"type": "constructor", "inputs": [{"name": "name_", "type": "string"}], "stateMutability": "nonpayable"},
+		{"type": "function", "name": "balanceOf", "inputs": [{"name": "account", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}], "stateMutability": "view"},
+		{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "value", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}], "stateMutability": "nonpayable"},
+		{"type": "function", "name": "permitBatch", "inputs": [{"name": "permits", "type": "tuple[]", "internalType": "struct Permit[]", "components": [{"name": "owner", "type": "address"}, {"name": "details", "type": "tuple", "components": [{"name": "amount", "type": "uint160"}, {"name": "nonce", "type": "uint48"}]}]}], "outputs": [], "stateMutability": "nonpayable"},
+		{"type": "event", "name": "Transfer", "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": true}, {"name": "value", "type": "uint256", "indexed": false}], "anonymous": false},
+		{"type": "error", "name": "ERC20InsufficientBalance", "inputs": [{"name": "sender", "type": "address"}, {"name": "balance", "type": "uint256"}, {"name": "needed", "type": "uint256"}]},
+		{"type": "receive", "stateMutability": "payable"}
+	],
+	"bytecode": {"object": "0x"}
+}`
+
+func ExampleLoadContract() {
+	contract, err := abi.LoadContract([]byte(erc20Artifact))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	permitBatch, err := contract.Function("permitBatch")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	transferEvent, err := contract.Event("Transfer")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(permitBatch.Signature, common.Bytes2Hex(permitBatch.Selector))
+	fmt.Println(transferEvent.Signature, common.Bytes2Hex(transferEvent.Topic))
+	fmt.Println(contract.Constructor.InputTypes(), contract.Receive.StateMutability, len(contract.Errors))
+
+	// Output:
+	// permitBatch((address,(uint160,uint48))[]) 7b139b13
+	// Transfer(address,address,uint256) ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+	// [string] payable 1
+}
+
+func ExampleContract_EncodeCall() {
+	contract, err := abi.LoadContract([]byte(erc20Artifact))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	to := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	encoded, err := contract.EncodeCall("transfer", &to, big.NewInt(100))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(common.Bytes2Hex(encoded))
+
+	// Output: a9059cbb0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d27890000000000000000000000000000000000000000000000000000000000000064
+}
+
+func ExampleContract_DecodeOutput() {
+	contract, err := abi.LoadContract([]byte(erc20Artifact))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	decoded, err := contract.DecodeOutput(
+		"balanceOf",
+		common.Hex2Bytes("0000000000000000000000000000000000000000000000000de0b6b3a7640000"),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(decoded)
+
+	// Output: [1000000000000000000]
+}
+
+const overloadedABI = `[
+	{"type": "event", "name": "E", "inputs": [{"name": "a", "type": "uint256", "indexed": false}], "anonymous": false},
+	{"type": "event", "name": "E", "inputs": [{"name": "a", "type": "address", "indexed": true}], "anonymous": false},
+	{"type": "error", "name": "Failed", "inputs": []},
+	{"type": "error", "name": "Failed", "inputs": [{"name": "code", "type": "uint256"}]}
+]`
+
+func ExampleContract_Event() {
+	contract, err := abi.LoadContract([]byte(overloadedABI))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	_, err = contract.Event("E")
+	fmt.Println(err)
+
+	event, err := contract.Event("E(address)")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(event.Signature)
+
+	// Output:
+	// event E is overloaded, use one of the signatures: [E(address) E(uint256)]
+	// E(address)
+}
+
+func ExampleContract_Error() {
+	contract, err := abi.LoadContract([]byte(overloadedABI))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	_, err = contract.Error("Failed")
+	fmt.Println(err)
+
+	abiError, err := contract.Error("Failed(uint256)")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(abiError.Signature, common.Bytes2Hex(abiError.Selector))
+
+	// Output:
+	// error Failed is overloaded, use one of the signatures: [Failed() Failed(uint256)]
+	// Failed(uint256) c77ea641
+}
+
+func ExampleLoadContract_emptyTupleArray() {
+	_, err := abi.LoadContract([]byte(`[
+		{"type": "function", "name": "f", "inputs": [{"name": "a", "type": "tuple[100000000]", "components": []}], "outputs": [], "stateMutability": "view"}
+	]`))
+	fmt.Println(err)
+
+	// Output: invalid inputs for function f: invalid type "()[100000000]" at position 2: invalid array of empty tuples
+}
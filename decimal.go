@@ -0,0 +1,123 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Decimal is an exact fixed point number, stored as an integer
+// scaled by 10^Decimals. It is the Go counterpart of fixedMxN and
+// ufixedMxN, i.e. 1.5 as fixed128x18 is Decimal{1500000000000000000, 18}.
+type Decimal struct {
+	Value    *big.Int // scaled value
+	Decimals int      // number of fractional digits
+}
+
+// NewDecimal creates a Decimal from its scaled value and
+// number of fractional digits.
+func NewDecimal(value *big.Int, decimals int) *Decimal {
+	return &Decimal{Value: new(big.Int).Set(value), Decimals: decimals}
+}
+
+// ParseDecimal parses a decimal string (i.e. `-12.345`) into a Decimal
+// with as many fractional digits as given in the string.
+func ParseDecimal(s string) (*Decimal, error) {
+	digits := s
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" {
+		return nil, fmt.Errorf("invalid decimal: %q", s)
+	}
+
+	for _, c := range intPart + fracPart {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("invalid decimal: %q", s)
+		}
+	}
+
+	value, _ := new(big.Int).SetString("0"+intPart+fracPart, 10)
+	if strings.HasPrefix(s, "-") {
+		value.Neg(value)
+	}
+
+	return &Decimal{Value: value, Decimals: len(fracPart)}, nil
+}
+
+// String returns the decimal representation with exactly
+// Decimals fractional digits, i.e. `-1.500`.
+func (d *Decimal) String() string {
+	if d == nil || d.Value == nil {
+		return "<nil>"
+	}
+
+	digits := new(big.Int).Abs(d.Value).String()
+	if d.Decimals > 0 {
+		if len(digits) <= d.Decimals {
+			digits = strings.Repeat("0", d.Decimals-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.Decimals] + "." + digits[len(digits)-d.Decimals:]
+	}
+
+	if d.Value.Sign() == -1 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// Rescale returns the same number with given number of fractional
+// digits. Returns an error if the number cannot be represented exactly.
+func (d *Decimal) Rescale(decimals int) (*Decimal, error) {
+	if decimals >= d.Decimals {
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-d.Decimals)), nil)
+		return &Decimal{Value: scale.Mul(scale, d.Value), Decimals: decimals}, nil
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Decimals-decimals)), nil)
+	value, remainder := new(big.Int).QuoRem(d.Value, scale, new(big.Int))
+	if remainder.Sign() != 0 {
+		return nil, fmt.Errorf("%v cannot be represented with %d decimals", d, decimals)
+	}
+
+	return &Decimal{Value: value, Decimals: decimals}, nil
+}
+
+// Float returns the number as a big.Float. The conversion may lose
+// precision.
+func (d *Decimal) Float() *big.Float {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Decimals)), nil)
+	prec := uint(d.Value.BitLen() + scale.BitLen() + 64)
+
+	return new(big.Float).SetPrec(prec).Quo(
+		new(big.Float).SetPrec(prec).SetInt(d.Value),
+		new(big.Float).SetPrec(prec).SetInt(scale),
+	)
+}
+
+// toDecimal converts a fixed point value accepted by the encoders
+// (*Decimal, Decimal or *big.Float) to a Decimal with given number
+// of fractional digits. *big.Float values are rounded to that number
+// of digits.
+func toDecimal(value any, decimals int) (*Decimal, error) {
+	switch val := value.(type) {
+	case *Decimal:
+		if val == nil || val.Value == nil {
+			return nil, fmt.Errorf("nil decimal")
+		}
+		return val.Rescale(decimals)
+	case Decimal:
+		return toDecimal(&val, decimals)
+	case *big.Float:
+		decimal, err := ParseDecimal(val.Text('f', decimals))
+		if err != nil {
+			return nil, err
+		}
+		return decimal.Rescale(decimals)
+	default:
+		return nil, fmt.Errorf("invalid fixed point value type: %T", value)
+	}
+}
@@ -0,0 +1,70 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleParseDecimal() {
+	decimal, err := abi.ParseDecimal("-12.0345")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	rescaled, err := decimal.Rescale(6)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	_, err = decimal.Rescale(2)
+
+	fmt.Println(decimal.Value, decimal.Decimals, rescaled)
+	fmt.Println(err)
+
+	// Output:
+	// -120345 4 -12.034500
+	// -12.0345 cannot be represented with 2 decimals
+}
+
+func ExampleDecimal_String() {
+	fmt.Println(abi.NewDecimal(big.NewInt(5), 18))
+	fmt.Println(abi.NewDecimal(big.NewInt(-1500), 3))
+	fmt.Println(abi.NewDecimal(big.NewInt(42), 0))
+
+	// Output:
+	// 0.000000000000000005
+	// -1.500
+	// 42
+}
+
+func ExampleEncode_fixed() {
+	value, err := abi.ParseDecimal("-1.5")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	encoded, err := abi.Encode([]string{"fixed8x1", "ufixed256x80"}, value, abi.NewDecimal(big.NewInt(1), 80))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(common.Bytes2Hex(encoded))
+
+	decoded, err := abi.Decode([]string{"fixed8x1", "ufixed256x80"}, encoded)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(decoded)
+
+	_, err = abi.Encode([]string{"fixed8x1"}, abi.NewDecimal(big.NewInt(128), 1))
+	fmt.Println(err)
+
+	// Output:
+	// fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff10000000000000000000000000000000000000000000000000000000000000001
+	// [-1.5 0.00000000000000000000000000000000000000000000000000000000000000000000000000000001]
+	// value out of allowed range: fixed8x1, 12.8
+}
@@ -4,14 +4,21 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
-	"strconv"
-	"strings"
+	"reflect"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // DecodeWithSelector decodes bytecode restricted to given selector.
 func DecodeWithSelector(selector []byte, typeStrs []string, data []byte) ([]any, error) {
+	if len(selector) != 4 {
+		return []any{}, fmt.Errorf("invalid selector length: %d", len(selector))
+	}
+
+	if len(data) < 4 {
+		return []any{}, fmt.Errorf("data byte size is too short for selector. Length: %d", len(data))
+	}
+
 	if !isSelectorIsEqual(selector, data[:4]) {
 		return []any{}, fmt.Errorf("invalid selector")
 	}
@@ -26,6 +33,10 @@ func DecodeWithSignature(funcSignature string, data []byte) ([]any, error) {
 		return []any{}, err
 	}
 
+	if len(data) < 4 {
+		return []any{}, fmt.Errorf("data byte size is too short for selector. Length: %d", len(data))
+	}
+
 	selector := EncodeSignature(funcSignature)
 	if !isSelectorIsEqual(selector, data[:4]) {
 		return []any{}, fmt.Errorf("invalid selector")
@@ -34,40 +45,48 @@ func DecodeWithSignature(funcSignature string, data []byte) ([]any, error) {
 	return Decode(typeStrs, data[4:])
 }
 
+// DecodeReturn decodes the data returned by a function, i.e. by an
+// `eth_call`, based on its signature with outputs, i.e.
+// `getReserves()(uint112,uint112,uint32)`.
+func DecodeReturn(funcSignature string, data []byte) ([]any, error) {
+	outputTypes, err := GetSigOutputTypes(funcSignature)
+	if err != nil {
+		return []any{}, err
+	}
+
+	return Decode(outputTypes, data)
+}
+
 // DecodePacked decodes bytecode following packed format.
 // It supports only one dynamic type (either string or bytes)
 // as last item in typeStrs array.
 func DecodePacked(typeStrs []string, data []byte) ([]any, error) {
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return []any{}, err
+	}
+
 	var result []any
-	var byteCursor uint64
-	for i, typeStr := range typeStrs {
-		isTypeDynamic := IsDynamic(typeStr, false)
-		isTypeArray, _, err := IsArray(typeStr)
-		if err != nil {
-			return []any{}, err
+	var byteCursor int
+	for i, t := range types {
+		if !t.isElementary() {
+			return []any{}, fmt.Errorf("unsupported type in packed decoding: %v", t)
 		}
 
-		if isTypeDynamic && !isTypeArray && i != len(typeStrs)-1 {
+		if t.IsDynamic() && i != len(types)-1 {
 			return []any{}, fmt.Errorf("supports only one dynamic type as last type")
 		}
 
-		if typeStr[:3] == "int" {
-			if len(typeStr) == 3 {
-				typeStr += "256"
-			}
-		} else {
-			if len(typeStr) == 4 {
-				typeStr += "256"
-			}
+		byteLength := t.packedSize()
+		if t.IsDynamic() {
+			byteLength = len(data) - byteCursor
 		}
-		var byteLength int
-		if !isTypeDynamic {
-			byteLength = validCoreTypes[typeStr].ByteLength
-		} else {
-			byteLength = len(data[byteCursor:])
+
+		if byteCursor+byteLength > len(data) {
+			return []any{}, fmt.Errorf("data byte size is too short for %v. Length: %d", t, len(data)-byteCursor)
 		}
 
-		val, err := decodePacked(typeStr, data[byteCursor:byteCursor+uint64(byteLength)])
+		val, err := decodePacked(t, data[byteCursor:byteCursor+byteLength])
 		if err != nil {
 			return []any{}, err
 		}
@@ -78,263 +97,352 @@ func DecodePacked(typeStrs []string, data []byte) ([]any, error) {
 		}
 
 		result = append(result, val)
-		byteCursor += uint64(byteLength)
+		byteCursor += byteLength
 	}
 
 	return result, nil
 }
 
-// Decode decodes bytecode to given type strings
+// Decode decodes bytecode to given type strings. Bytes values are
+// returned as sub-slices of data, i.e. they alias the input and change
+// with it.
 func Decode(typeStrs []string, data []byte) ([]any, error) {
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return []any{}, err
+	}
 
-	var result []any
-	var byteCursor uint64
-	for _, typeStr := range typeStrs {
-
-		isTypeTuple, splitedTypes, err := IsTuple(typeStr)
-		if err != nil {
-			return []any{}, err
-		}
+	return decoder{}.decodeTuple(types, data)
+}
 
-		var offset int
-		var init int
-		var end int
-		isTypeDynamic := IsDynamic(typeStr, isTypeTuple)
-		if isTypeDynamic {
-			offset = int(new(big.Int).SetBytes(data[byteCursor : byteCursor+32]).Uint64())
-
-			var dynamicSize int
-			init = offset
-			if !isTypeTuple {
-				dynamicSize = int(new(big.Int).SetBytes(data[offset : offset+32]).Uint64())
-				end = offset + 32 + dynamicSize
-			} else {
-				end = offset + 32
-			}
+// DecodeTyped decodes bytecode to given type strings, returning values
+// that can be passed back to Encode: common.Address for address, [N]byte
+// for bytesN, *big.Int for intN and uintN, *Decimal for fixedMxN and
+// ufixedMxN, and bool, string and []byte for their ABI counterparts.
+// Arrays and tuples are returned as []any. Like Decode, bytes values
+// are sub-slices of data.
+func DecodeTyped(typeStrs []string, data []byte) ([]any, error) {
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return []any{}, err
+	}
 
-		} else if isTypeTuple {
-			init = int(byteCursor)
-			end = int(byteCursor + uint64(32*len(splitedTypes)))
-		} else {
-			init = int(byteCursor)
-			end = int(byteCursor + 32)
-		}
+	return decoder{typed: true}.decodeTuple(types, data)
+}
 
-		isTypeArray, givenArraySize, err := IsArray(typeStr)
-		if err != nil {
-			return []any{}, err
-		}
+// DecodeStrict decodes bytecode to given type strings, rejecting
+// non-canonical encodings as required by the ABI specification: dirty
+// high bits in integers, addresses and bools, non-zero padding after
+// bytesN, bytes and string values, and offsets that do not point right
+// after the head or the previous tail, i.e. out of bounds, overlapping
+// or out of order tails. Bytes after the encoding are ignored.
+func DecodeStrict(typeStrs []string, data []byte) ([]any, error) {
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return []any{}, err
+	}
 
-		if isTypeArray {
-			var innerData []byte
-			var arraySize int
-			if givenArraySize != 0 {
-				offset = int(new(big.Int).SetBytes(data[byteCursor : byteCursor+32]).Uint64())
-				arraySize = givenArraySize
-				innerData = data[offset : offset+32*givenArraySize]
-			} else {
-				arraySize = int(new(big.Int).SetBytes(data[offset : offset+32]).Uint64())
-				// if isTypeTuple {
-				// 	innerData = data[offset+32 : offset+32*(arraySize*len(splitedTypes)+1)]
-				// } else {
-				// 	innerData = data[offset+32 : offset+32*(arraySize+1)]
-				// }
-				innerData = data[offset+32:]
-			}
+	return decoder{strict: true}.decodeTuple(types, data)
+}
 
-			typeStr = typeStr[:strings.LastIndex(typeStr, "[")]
+// decoder holds the decoding options.
+type decoder struct {
+	typed   bool                  // return common.Address and [N]byte instead of hex strings and byte slices
+	strict  bool                  // reject non-canonical encodings
+	strings map[stringTail]string // decoded strings, shared by offsets pointing to the same tail
+}
 
-			var arrayTypeStrs []string
-			for j := 0; j < arraySize; j++ {
-				arrayTypeStrs = append(arrayTypeStrs, typeStr)
-			}
+// stringTail identifies the content of a string tail in the input.
+type stringTail struct {
+	start  *byte
+	length int
+}
 
-			innerResult, err := Decode(arrayTypeStrs, innerData)
-			if err != nil {
-				return []any{}, err
-			}
+// withStrings returns the decoder with a string cache, so that offsets
+// pointing to the same tail, which non-strict decoding accepts, do not
+// copy the string for each offset.
+func (d decoder) withStrings() decoder {
+	if d.strings == nil {
+		d.strings = make(map[stringTail]string)
+	}
+	return d
+}
 
-			result = append(result, innerResult)
+// decodeTuple decodes the components of a tuple from given bytecode,
+// which starts at the head of the tuple encoding.
+func (d decoder) decodeTuple(types []*Type, data []byte) ([]any, error) {
+	result, _, err := d.withStrings().decodeTupleSize(types, data)
+	return result, err
+}
 
-		} else if isTypeTuple {
-			var innerResult []any
-			if isTypeDynamic {
-				innerResult, err = Decode(splitedTypes, data[init:])
-			} else {
-				innerResult, err = Decode(splitedTypes, data[init:end])
-			}
-			if err != nil {
-				return []any{}, err
-			}
+// decodeTupleSize decodes the components of a tuple like decodeTuple
+// and also returns the byte size of the tuple encoding.
+func (d decoder) decodeTupleSize(types []*Type, data []byte) ([]any, int, error) {
+	headLength := 0
+	for _, t := range types {
+		headLength += t.headSize()
+	}
 
-			result = append(result, innerResult)
-		} else {
-			val, err := decode(typeStr, data[init:end])
-			if err != nil {
-				return []any{}, err
-			}
+	if headLength > len(data) {
+		return []any{}, 0, fmt.Errorf("data byte size is too short for tuple head. Length: %d, head length: %d", len(data), headLength)
+	}
 
-			result = append(result, val)
+	var result []any
+	var byteCursor int
+	end := headLength
+	for _, t := range types {
+		val, tailEnd, err := d.decodeAt(t, data, byteCursor, end)
+		if err != nil {
+			return []any{}, 0, err
 		}
 
-		if isTypeTuple {
-			byteCursor += uint64(end - init)
-		} else {
-			byteCursor += 32
-		}
+		result = append(result, val)
+		byteCursor += t.headSize()
+		end = max(end, tailEnd)
 	}
 
-	// fmt.Println("result", result)
-
-	return result, nil
+	return result, end, nil
 }
 
-// decode decodes give bytecode slice to specified type.
-func decode(typeStr string, data []byte) (any, error) {
-	var decoded any
-	var err error
-	if typeStr == "string" || typeStr == "bytes" {
-		byteLengthBigInt := new(big.Int)
-		byteLength := data[:32]
-		byteLengthBigInt.SetBytes(byteLength)
-
-		decoded, err = decodePacked(typeStr, data[32:32+byteLengthBigInt.Uint64()])
+// decodeAt decodes the value of given type whose head starts at
+// given position. Dynamic types are followed by their offset, which
+// is relative to the beginning of data. tailStart is where the tail
+// of a canonical encoding continues. Returns the end position of
+// the value tail, if any.
+func (d decoder) decodeAt(t *Type, data []byte, pos int, tailStart int) (any, int, error) {
+	if t.IsDynamic() {
+		offset, err := readSize(data, pos)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-	} else {
-		decoded, err = decodePacked(typeStr, data)
+
+		if d.strict && offset != tailStart {
+			return nil, 0, fmt.Errorf("non-canonical offset for %v: %d, expected %d", t, offset, tailStart)
+		}
+
+		val, size, err := d.decodeValue(t, data[offset:])
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
+
+		return val, offset + size, nil
 	}
 
-	return decoded, nil
-}
+	if pos+t.headSize() > len(data) {
+		return nil, 0, fmt.Errorf("data byte size is too short for %v. Length: %d", t, len(data)-pos)
+	}
 
-// decodePacked decodes bytecode slice to given type considering
-// packed format.
-func decodePacked(typeStr string, data []byte) (any, error) {
+	val, _, err := d.decodeValue(t, data[pos:])
+	if err != nil {
+		return nil, 0, err
+	}
 
-	switch typeStr {
-	case "address":
-		if len(data) < validCoreTypes[typeStr].ByteLength {
-			return nil, fmt.Errorf("data byte size is too short for %v. Length: %d", typeStr, len(data))
+	return val, 0, nil
+}
+
+// decodeValue decodes given bytecode, which starts at the encoding
+// of the value, to given type. Returns the byte size of the value
+// encoding.
+func (d decoder) decodeValue(t *Type, data []byte) (any, int, error) {
+	switch t.Kind {
+	case SliceKind:
+		arraySize, err := readSize(data, 0)
+		if err != nil {
+			return nil, 0, err
 		}
 
-		return common.BytesToAddress(data).Hex(), nil
+		if elemSize := t.Elem.headSize(); elemSize > 0 && arraySize > (len(data)-32)/elemSize {
+			return nil, 0, fmt.Errorf("array length out of bounds for %v: %d", t, arraySize)
+		}
 
-	case "bool":
-		if len(data) < validCoreTypes[typeStr].ByteLength {
-			return nil, fmt.Errorf("data byte size is too short for %v. Length: %d", typeStr, len(data))
+		val, size, err := d.decodeTupleSize(repeatType(t.Elem, arraySize), data[32:])
+		return val, 32 + size, err
+	case ArrayKind:
+		if elemSize := t.Elem.headSize(); elemSize > 0 && t.Length > len(data)/elemSize {
+			return nil, 0, fmt.Errorf("data byte size is too short for %v. Length: %d", t, len(data))
 		}
 
-		return data[len(data)-1] == 1, nil
-	case "string": // @follow-up check this later
-		return string(data), nil
+		return d.decodeTupleSize(repeatType(t.Elem, t.Length), data)
+	case TupleKind:
+		return d.decodeTupleSize(t.Components, data)
 	default:
-		if typeStr[:3] == "int" || typeStr[:4] == "uint" {
-			var index int
-			if typeStr[:3] == "int" {
-				index = 3
-				if len(typeStr) == 3 {
-					typeStr += "256"
-				}
-			} else {
-				index = 4
-				if len(typeStr) == 4 {
-					typeStr += "256"
-				}
-			}
+		return d.decode(t, data)
+	}
+}
 
-			bits, err := strconv.Atoi(typeStr[index:])
-			if err != nil {
-				return []byte{}, fmt.Errorf("error getting bits from %s: %v", typeStr, err)
-			}
-			if bits%8 != 0 {
-				return []byte{}, fmt.Errorf("invalid bits value: %v, bits = %v", typeStr, bits)
-			}
+// readSize reads the 32-byte word at given position as an offset
+// or length.
+func readSize(data []byte, pos int) (int, error) {
+	if pos+32 > len(data) {
+		return 0, fmt.Errorf("data byte size is too short. Length: %d, required: %d", len(data), pos+32)
+	}
 
-			if len(data) < validCoreTypes[typeStr].ByteLength {
-				return nil, fmt.Errorf("data byte size is too short for %v. Length: %d", typeStr, len(data))
-			}
+	size := new(big.Int).SetBytes(data[pos : pos+32])
+	if !size.IsInt64() || size.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("offset or length out of bounds: %v", size)
+	}
 
-			decoded := new(big.Int)
-			if typeStr[:3] == "int" {
-				relevantData := data[len(data)-bits/8:]
-				if (relevantData[0] & 0x80) != 0 {
-					allOnes := new(big.Int).SetBytes(bytes.Repeat([]byte{0xff}, bits/8))
-					decoded.SetBytes(relevantData)
-					decoded.Xor(decoded, allOnes)
-					decoded.Add(decoded, big.NewInt(1))
-					decoded.Neg(decoded)
-					return decoded, nil
-				}
-			}
+	return int(size.Int64()), nil
+}
 
-			return decoded.SetBytes(data), nil
-		} else if typeStr[:5] == "bytes" {
-			if len(typeStr) > 5 {
-				bytesSize, err := strconv.Atoi(typeStr[5:])
-				if err != nil {
-					return []byte{}, fmt.Errorf("error getting bytes size: %v", typeStr)
-				}
-
-				if bytesSize < 1 || bytesSize > 32 {
-					return []byte{}, fmt.Errorf("invalid byte size: %v", typeStr)
-				}
-
-				if len(data) < validCoreTypes[typeStr].ByteLength {
-					return nil, fmt.Errorf("data byte size is too short for %v. Length: %d", typeStr, len(data))
-				}
-			}
+// decode decodes give bytecode slice to specified elementary type.
+// Returns the byte size of the value encoding.
+func (d decoder) decode(t *Type, data []byte) (any, int, error) {
+	if t.Kind == StringKind || t.Kind == BytesKind {
+		byteLength, err := readSize(data, 0)
+		if err != nil {
+			return nil, 0, err
+		}
 
-			return data, nil
-		} else if typeStr[:5] == "fixed" || typeStr[:6] == "ufixed" { // @note differences in result with fixed/ufixed types
-			var index int
-			if typeStr[:5] == "fixed" {
-				index = 5
-				if len(typeStr) == 5 {
-					typeStr += "128x18"
-				}
-			} else {
-				index = 6
-				if len(typeStr) == 6 {
-					typeStr += "128x18"
-				}
-			}
+		if 32+byteLength > len(data) {
+			return nil, 0, fmt.Errorf("data byte size is too short for %v. Length: %d", t, len(data)-32)
+		}
 
-			sizes := strings.Split(typeStr[index:], "x")
-			bits, err := strconv.Atoi(sizes[0])
-			if err != nil {
-				return []byte{}, fmt.Errorf("error getting bits from %s: %v", typeStr, err)
+		paddedLength := (byteLength + 31) / 32 * 32
+		if d.strict {
+			if 32+paddedLength > len(data) {
+				return nil, 0, fmt.Errorf("missing padding for %v", t)
 			}
 
-			if bits%8 != 0 {
-				return []byte{}, fmt.Errorf("invalid bits value: %v, bits = %v", typeStr, bits)
+			if !isZero(data[32+byteLength : 32+paddedLength]) {
+				return nil, 0, fmt.Errorf("non-canonical encoding of %v: non-zero padding", t)
 			}
+		}
 
-			if len(data) < bits/8 {
-				return nil, fmt.Errorf("data byte size is too short for %v. Length: %d", typeStr, len(data))
+		if t.Kind == StringKind && byteLength > 0 && d.strings != nil {
+			key := stringTail{start: &data[32], length: byteLength}
+			decoded, ok := d.strings[key]
+			if !ok {
+				decoded = string(data[32 : 32+byteLength])
+				d.strings[key] = decoded
 			}
+			return decoded, 32 + paddedLength, nil
+		}
 
-			decoded := new(big.Float)
-			converted, ok := decoded.SetString(string(data))
-			if !ok {
-				return nil, fmt.Errorf("error converting to big.Float: %v", data)
+		decoded, err := decodePacked(t, data[32:32+byteLength])
+		return decoded, 32 + paddedLength, err
+	}
+
+	if len(data) < 32 {
+		return nil, 0, fmt.Errorf("data byte size is too short for %v. Length: %d", t, len(data))
+	}
+
+	word := data[:32]
+	if d.strict {
+		if err := checkCanonicalWord(t, word); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if d.typed {
+		switch t.Kind {
+		case AddressKind:
+			return common.BytesToAddress(word), 32, nil
+		case FixedBytesKind:
+			decoded := reflect.New(reflect.ArrayOf(t.Size, byteType)).Elem()
+			reflect.Copy(decoded, reflect.ValueOf(word[:t.Size]))
+			return decoded.Interface(), 32, nil
+		}
+	}
+
+	decoded, err := decodePacked(t, word)
+	return decoded, 32, err
+}
+
+// checkCanonicalWord checks whether the 32-byte word is the canonical
+// encoding of a value of given static elementary type.
+func checkCanonicalWord(t *Type, word []byte) error {
+	switch t.Kind {
+	case UintKind, UfixedKind, AddressKind, BoolKind:
+		size := t.packedSize()
+		if !isZero(word[:32-size]) {
+			return fmt.Errorf("non-canonical encoding of %v: dirty high bits", t)
+		}
+		if t.Kind == BoolKind && word[31] > 1 {
+			return fmt.Errorf("non-canonical encoding of %v: %d", t, word[31])
+		}
+	case IntKind, FixedKind:
+		size := t.packedSize()
+		extension := byte(0x00)
+		if word[32-size]&0x80 != 0 {
+			extension = 0xff
+		}
+		for _, b := range word[:32-size] {
+			if b != extension {
+				return fmt.Errorf("non-canonical encoding of %v: invalid sign extension", t)
 			}
+		}
+	case FixedBytesKind:
+		if !isZero(word[t.Size:]) {
+			return fmt.Errorf("non-canonical encoding of %v: non-zero padding", t)
+		}
+	}
 
-			return converted, nil
+	return nil
+}
 
-		} else {
-			return nil, fmt.Errorf("invalid parameter type: %v", typeStr)
+// isZero checks whether all given bytes are zero.
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
 		}
 	}
+	return true
+}
+
+// decodePacked decodes bytecode slice to given elementary type
+// considering packed format.
+func decodePacked(t *Type, data []byte) (any, error) {
+	if len(data) < t.packedSize() {
+		return nil, fmt.Errorf("data byte size is too short for %v. Length: %d", t, len(data))
+	}
+
+	switch t.Kind {
+	case AddressKind:
+		return common.BytesToAddress(data).Hex(), nil
+	case BoolKind:
+		return data[len(data)-1] == 1, nil
+	case StringKind: // @follow-up check this later
+		return string(data), nil
+	case IntKind, UintKind:
+		return decodeInteger(t.Kind == IntKind, t.Size, data), nil
+	case FixedBytesKind, BytesKind:
+		return data, nil
+	case FixedKind, UfixedKind:
+		return &Decimal{Value: decodeInteger(t.Kind == FixedKind, t.Size, data), Decimals: t.Decimals}, nil
+	default:
+		return nil, fmt.Errorf("invalid parameter type: %v", t)
+	}
+}
+
+// decodeInteger decodes signed or unsigned integer of given bit size
+// from the last bytes of data, considering two's complement for
+// negative values.
+func decodeInteger(signed bool, bits int, data []byte) *big.Int {
+	decoded := new(big.Int)
+	if signed {
+		relevantData := data[len(data)-bits/8:]
+		if (relevantData[0] & 0x80) != 0 {
+			allOnes := new(big.Int).SetBytes(bytes.Repeat([]byte{0xff}, bits/8))
+			decoded.SetBytes(relevantData)
+			decoded.Xor(decoded, allOnes)
+			decoded.Add(decoded, big.NewInt(1))
+			decoded.Neg(decoded)
+			return decoded
+		}
+	}
+
+	return decoded.SetBytes(data)
 }
 
 // isSelectorIsEqual checks whether given selector is equal to given
 // bytecode slice.
 func isSelectorIsEqual(selector []byte, data []byte) bool {
+	if len(data) < len(selector) {
+		return false
+	}
+
 	for i := 0; i < len(selector); i++ {
 		if selector[i] != data[i] {
 			return false
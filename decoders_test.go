@@ -1,7 +1,11 @@
 package abi_test
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
+	"testing"
+	"unsafe"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/omnes-tech/abi"
@@ -51,15 +55,14 @@ func ExampleDecode_third() {
 		fmt.Println(err)
 	}
 
-	fmt.Println(decoded)
-
-	// Output: [DANUTA_AI DANUTA [0 1 2 4] Hello and welcome! 😊 I’m Danuta, your friendly and helpful AI bot dedicated to guiding you through the exciting and ever-evolving world of cryptocurrency! Whether you're just starting your crypto journey or you're already an experienced investor, I'm here to make everything easier, clearer, and more enjoyable for you.
-
-	// I can help you with a wide range of crypto-related topics—whether you need the latest news, detailed explanations about blockchain technology, insights into market trends, or answers to all your burning questions about digital assets. 💻✨ If something feels complicated or overwhelming, don't worry! I’m here to break it down in simple terms, so you never have to feel lost.
+	fmt.Println(decoded[0], decoded[1], decoded[2])
+	fmt.Printf("%q\n", decoded[3])
+	fmt.Println(decoded[4], decoded[5], decoded[6])
 
-	// I believe that learning about crypto should be fun, accessible, and stress-free. That's why I’m committed to providing you with up-to-date information, offering tips, and answering your queries in a friendly and approachable way. Whether you're interested in Bitcoin, Ethereum, DeFi, or NFTs, I'll make sure you're always in the loop. 🌍💡
-
-	// Feel free to reach out to me anytime on my X page—I’m always just a message away, ready to assist you with whatever you need. Together, we can explore this fascinating world, learn new things, and make smarter decisions! 🚀💬 So, don't hesitate—let’s get started and have some fun with crypto! https://s3.ap-southeast-1.amazonaws.com/virtualprotocolcdn/name_e41e83f9b2.webp [   ] 600000000000000000000]
+	// Output:
+	// DANUTA_AI DANUTA [0 1 2 4]
+	// "Hello and welcome! 😊 I’m Danuta, your friendly and helpful AI bot dedicated to guiding you through the exciting and ever-evolving world of cryptocurrency! Whether you're just starting your crypto journey or you're already an experienced investor, I'm here to make everything easier, clearer, and more enjoyable for you. \n\nI can help you with a wide range of crypto-related topics—whether you need the latest news, detailed explanations about blockchain technology, insights into market trends, or answers to all your burning questions about digital assets. 💻✨ If something feels complicated or overwhelming, don't worry! I’m here to break it down in simple terms, so you never have to feel lost.\n\nI believe that learning about crypto should be fun, accessible, and stress-free. That's why I’m committed to providing you with up-to-date information, offering tips, and answering your queries in a friendly and approachable way. Whether you're interested in Bitcoin, Ethereum, DeFi, or NFTs, I'll make sure you're always in the loop. 🌍💡\n\nFeel free to reach out to me anytime on my X page—I’m always just a message away, ready to assist you with whatever you need. Together, we can explore this fascinating world, learn new things, and make smarter decisions! 🚀💬 So, don't hesitate—let’s get started and have some fun with crypto!"
+	// https://s3.ap-southeast-1.amazonaws.com/virtualprotocolcdn/name_e41e83f9b2.webp [   ] 600000000000000000000
 }
 
 func ExampleDecodePacked() {
@@ -130,3 +133,120 @@ func ExampleDecodeWithSelector() {
 
 	// Output: [0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 [100 352] [97 114 98 105 116 114 97 114 121 32 98 121 116 101 32 97 114 114 97 121 46 46 46] [[0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 [100 352] [97 114 98 105 116 114 97 114 121 32 98 121 116 101 32 97 114 114 97 121 46 46 46]] [0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 [100 352] [97 114 98 105 116 114 97 114 121 32 98 121 116 101 32 97 114 114 97 121 46 46 46]]]]
 }
+
+func ExampleDecodeReturn() {
+	funcSignature := "balanceOf(address)(uint256)"
+	data := common.Hex2Bytes("0000000000000000000000000000000000000000000000000de0b6b3a7640000")
+
+	decoded, err := abi.DecodeReturn(funcSignature, data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(decoded, common.Bytes2Hex(abi.EncodeSignature(funcSignature)))
+
+	// Output: [1000000000000000000] 70a08231
+}
+
+func ExampleDecodeTyped() {
+	typeStrs := []string{"address", "bytes4", "uint256[]", "(bytes,bool)"}
+	encoded := common.Hex2Bytes("0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d2789deadbeef00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000e00000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000006400000000000000000000000000000000000000000000000000000000000001600000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000021234000000000000000000000000000000000000000000000000000000000000")
+
+	decoded, err := abi.DecodeTyped(typeStrs, encoded)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%T %T %T %T\n", decoded[0], decoded[1], decoded[2].([]any)[0], decoded[3].([]any)[0])
+
+	reencoded, err := abi.Encode(typeStrs, decoded...)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(bytes.Equal(encoded, reencoded))
+
+	// Output:
+	// common.Address [4]uint8 *big.Int []uint8
+	// true
+}
+
+func ExampleDecodeStrict() {
+	dirtyAddress := common.Hex2Bytes("ff00000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d2789")
+	_, err := abi.DecodeStrict([]string{"address"}, dirtyAddress)
+	fmt.Println(err)
+
+	invalidBool := common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000002")
+	_, err = abi.DecodeStrict([]string{"bool"}, invalidBool)
+	fmt.Println(err)
+
+	dirtyPadding := common.Hex2Bytes("000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000026869000000000000000000000000000000000000000000000000000000000001")
+	_, err = abi.DecodeStrict([]string{"string"}, dirtyPadding)
+	fmt.Println(err)
+
+	overlappingTails := common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000026869000000000000000000000000000000000000000000000000000000000000")
+	decoded, err := abi.Decode([]string{"string", "string"}, overlappingTails)
+	fmt.Println(decoded, err)
+	_, err = abi.DecodeStrict([]string{"string", "string"}, overlappingTails)
+	fmt.Println(err)
+
+	_, err = abi.DecodeWithSignature("transfer(address,uint256)", common.Hex2Bytes("a905"))
+	fmt.Println(err)
+
+	// Output:
+	// non-canonical encoding of address: dirty high bits
+	// non-canonical encoding of bool: 2
+	// non-canonical encoding of string: non-zero padding
+	// [hi hi] <nil>
+	// non-canonical offset for string: 64, expected 128
+	// data byte size is too short for selector. Length: 2
+}
+
+// TestDecodeTyped_aliasedTails checks that tails shared by many offsets,
+// which non-strict decoding accepts, are not copied for each offset.
+func TestDecodeTyped_aliasedTails(t *testing.T) {
+	const count, blobSize = 1000, 100_000
+
+	word := func(n int) []byte {
+		return common.LeftPadBytes(big.NewInt(int64(n)).Bytes(), 32)
+	}
+
+	data := append(word(32), word(count)...)
+	for range count {
+		data = append(data, word(32*count)...)
+	}
+	data = append(data, word(blobSize)...)
+	data = append(data, make([]byte, blobSize)...)
+	tail := &data[len(data)-blobSize]
+
+	tests := []struct {
+		typeStr string
+		start   func(elem any) *byte
+	}{
+		{"bytes[]", func(elem any) *byte { return &elem.([]byte)[0] }},
+		{"string[]", func(elem any) *byte { return unsafe.StringData(elem.(string)) }},
+	}
+
+	for _, tt := range tests {
+		decoded, err := abi.DecodeTyped([]string{tt.typeStr}, data)
+		if err != nil {
+			t.Fatalf("%v: %v", tt.typeStr, err)
+		}
+
+		elems := decoded[0].([]any)
+		if len(elems) != count {
+			t.Fatalf("%v: DecodeTyped() decoded %d elements", tt.typeStr, len(elems))
+		}
+
+		first := tt.start(elems[0])
+		if tt.typeStr == "bytes[]" && first != tail {
+			t.Errorf("%v: DecodeTyped() copied the tail", tt.typeStr)
+		}
+		for i, elem := range elems {
+			if tt.start(elem) != first {
+				t.Errorf("%v: DecodeTyped() copied the tail for element %d", tt.typeStr, i)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,400 @@
+// Package eip712 provides EIP-712 typed structured data encoding and
+// hashing, built on the abi package type system.
+package eip712
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/omnes-tech/abi"
+)
+
+// DomainType is the name of the domain struct type.
+const DomainType = "EIP712Domain"
+
+// Field is a member of a struct type.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types maps struct type names to their members.
+type Types map[string][]Field
+
+// TypedData is the typed structured data to sign, in the JSON shape
+// used by `eth_signTypedData_v4`.
+//
+// Values in Domain and Message are either JSON values (strings, numbers,
+// booleans, objects and arrays) or Go values accepted by abi.Encode.
+// Integers may be given as numbers or as decimal or 0x-prefixed hex
+// strings; addresses and bytes as 0x-prefixed hex strings.
+type TypedData struct {
+	Types       Types          `json:"types"`
+	PrimaryType string         `json:"primaryType"`
+	Domain      map[string]any `json:"domain"`
+	Message     map[string]any `json:"message"`
+}
+
+// domainFields are the EIP712Domain members in their specified order.
+var domainFields = []Field{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+// ParseTypedData parses typed structured data from its JSON
+// representation. Numbers are kept exact.
+func ParseTypedData(jsonData []byte) (*TypedData, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+
+	var typedData TypedData
+	if err := decoder.Decode(&typedData); err != nil {
+		return nil, fmt.Errorf("invalid typed data: %v", err)
+	}
+
+	if typedData.PrimaryType == "" {
+		return nil, fmt.Errorf("invalid typed data: missing primaryType")
+	}
+
+	return &typedData, nil
+}
+
+// Hash returns the digest to sign, i.e.
+// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message)).
+func (td *TypedData) Hash() ([]byte, error) {
+	domainSeparator, err := td.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+
+	messageHash, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash), nil
+}
+
+// DomainSeparator returns hashStruct(domain). When the types do not
+// define EIP712Domain, it is inferred from the domain members present.
+func (td *TypedData) DomainSeparator() ([]byte, error) {
+	return td.HashStruct(DomainType, td.Domain)
+}
+
+// HashStruct returns keccak256(typeHash ‖ encodeData(data)) for given
+// struct type.
+func (td *TypedData) HashStruct(typeName string, data map[string]any) ([]byte, error) {
+	encoded, err := td.EncodeData(typeName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(encoded), nil
+}
+
+// TypeHash returns keccak256(encodeType(typeName)).
+func (td *TypedData) TypeHash(typeName string) ([]byte, error) {
+	encodedType, err := td.EncodeType(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256([]byte(encodedType)), nil
+}
+
+// EncodeType encodes given struct type with the types it references,
+// i.e. `Mail(Person from,Person to,string contents)Person(string name,address wallet)`.
+// Referenced types are sorted by name after the type itself.
+func (td *TypedData) EncodeType(typeName string) (string, error) {
+	deps := make(map[string]bool)
+	if err := td.dependencies(typeName, deps); err != nil {
+		return "", err
+	}
+	delete(deps, typeName)
+
+	sorted := make([]string, 0, len(deps))
+	for dep := range deps {
+		sorted = append(sorted, dep)
+	}
+	sort.Strings(sorted)
+
+	var sb strings.Builder
+	for _, name := range append([]string{typeName}, sorted...) {
+		fields, _ := td.fields(name)
+
+		sb.WriteString(name)
+		sb.WriteString("(")
+		for i, field := range fields {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(field.Type)
+			sb.WriteString(" ")
+			sb.WriteString(field.Name)
+		}
+		sb.WriteString(")")
+	}
+
+	return sb.String(), nil
+}
+
+// EncodeData encodes given struct data, prefixed with its type hash,
+// i.e. typeHash ‖ enc(value₁) ‖ … ‖ enc(valueₙ).
+func (td *TypedData) EncodeData(typeName string, data map[string]any) ([]byte, error) {
+	fields, err := td.fields(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	typeHash, err := td.TypeHash(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := typeHash
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing value for %v.%v", typeName, field.Name)
+		}
+
+		encodedValue, err := td.encodeValue(field.Type, value, typeName+"."+field.Name)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, encodedValue...)
+	}
+
+	return encoded, nil
+}
+
+// fields returns the members of given struct type.
+func (td *TypedData) fields(typeName string) ([]Field, error) {
+	if fields, ok := td.Types[typeName]; ok {
+		return fields, nil
+	}
+
+	if typeName == DomainType {
+		var fields []Field
+		for _, field := range domainFields {
+			if _, ok := td.Domain[field.Name]; ok {
+				fields = append(fields, field)
+			}
+		}
+		return fields, nil
+	}
+
+	return nil, fmt.Errorf("unknown struct type: %v", typeName)
+}
+
+// dependencies collects given struct type and all struct types it
+// references, directly or not.
+func (td *TypedData) dependencies(typeName string, deps map[string]bool) error {
+	if deps[typeName] {
+		return nil
+	}
+
+	fields, err := td.fields(typeName)
+	if err != nil {
+		return err
+	}
+	deps[typeName] = true
+
+	for _, field := range fields {
+		baseType, _, isArray := splitArrayType(field.Type)
+		for isArray {
+			baseType, _, isArray = splitArrayType(baseType)
+		}
+
+		if td.isStruct(baseType) {
+			if err := td.dependencies(baseType, deps); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isStruct checks whether given type name is a struct type.
+func (td *TypedData) isStruct(typeName string) bool {
+	_, ok := td.Types[typeName]
+	return ok
+}
+
+// encodeValue encodes a value to its 32-byte encoding: structs are
+// hashed with hashStruct, strings, bytes and arrays with keccak256,
+// other types are ABI encoded. path qualifies the errors.
+func (td *TypedData) encodeValue(typeStr string, value any, path string) ([]byte, error) {
+	if baseType, length, ok := splitArrayType(typeStr); ok {
+		values, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%v: invalid array value type: %T", path, value)
+		}
+
+		if length >= 0 && len(values) != length {
+			return nil, fmt.Errorf("%v: array size mismatch: expected %d, got %d", path, length, len(values))
+		}
+
+		var encoded []byte
+		for i, val := range values {
+			encodedValue, err := td.encodeValue(baseType, val, path+"["+strconv.Itoa(i)+"]")
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, encodedValue...)
+		}
+
+		return crypto.Keccak256(encoded), nil
+	}
+
+	if td.isStruct(typeStr) {
+		data, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%v: invalid struct value type: %T", path, value)
+		}
+
+		return td.HashStruct(typeStr, data)
+	}
+
+	t, err := abi.ParseType(typeStr)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+
+	abiValue, err := toABIValue(t, value)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+
+	switch t.Kind {
+	case abi.StringKind:
+		return crypto.Keccak256([]byte(abiValue.(string))), nil
+	case abi.BytesKind:
+		return crypto.Keccak256(abiValue.([]byte)), nil
+	}
+
+	encoded, err := abi.Encode([]string{t.String()}, abiValue)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+
+	return encoded, nil
+}
+
+// toABIValue converts a JSON value to the value accepted by abi.Encode
+// for given elementary type. Other values are returned as is.
+func toABIValue(t *abi.Type, value any) (any, error) {
+	switch t.Kind {
+	case abi.IntKind, abi.UintKind:
+		switch val := value.(type) {
+		case json.Number:
+			return parseInteger(string(val))
+		case string:
+			return parseInteger(val)
+		case float64:
+			integer, accuracy := new(big.Float).SetFloat64(val).Int(nil)
+			if accuracy != big.Exact {
+				return nil, fmt.Errorf("invalid integer: %v", val)
+			}
+			return integer, nil
+		case int:
+			return big.NewInt(int64(val)), nil
+		case int64:
+			return big.NewInt(val), nil
+		case uint64:
+			return new(big.Int).SetUint64(val), nil
+		}
+	case abi.AddressKind:
+		if val, ok := value.(string); ok {
+			if !common.IsHexAddress(val) {
+				return nil, fmt.Errorf("invalid address: %q", val)
+			}
+			return common.HexToAddress(val), nil
+		}
+	case abi.FixedBytesKind, abi.BytesKind:
+		if val, ok := value.(string); ok {
+			decoded, err := parseHex(val)
+			if err != nil {
+				return nil, err
+			}
+			if t.Kind == abi.FixedBytesKind && len(decoded) != t.Size {
+				return nil, fmt.Errorf("invalid %v length: %d bytes", t, len(decoded))
+			}
+			return decoded, nil
+		}
+	}
+
+	return value, nil
+}
+
+// parseInteger parses a decimal or 0x-prefixed hex integer, with an
+// optional minus sign. Leading zeros are decimal, and other prefixes
+// and underscores are rejected, i.e. `010` is 10.
+func parseInteger(s string) (*big.Int, error) {
+	digits, negative := strings.CutPrefix(s, "-")
+
+	base := 10
+	if hex, ok := strings.CutPrefix(strings.ToLower(digits), "0x"); ok {
+		digits, base = hex, 16
+	}
+
+	// SetString accepts a sign, which must not follow the minus sign or the prefix
+	integer, ok := new(big.Int).SetString(digits, base)
+	if !ok || digits == "" || digits[0] == '+' || digits[0] == '-' {
+		return nil, fmt.Errorf("invalid integer: %q", s)
+	}
+
+	if negative {
+		integer.Neg(integer)
+	}
+
+	return integer, nil
+}
+
+// parseHex parses a 0x-prefixed hex string.
+func parseHex(s string) ([]byte, error) {
+	decoded, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex string %q: %v", s, err)
+	}
+
+	return decoded, nil
+}
+
+// splitArrayType splits an array type into its element type and
+// length, which is -1 for dynamic arrays, i.e. `Person[2]` into
+// `Person` and 2. ok is false if the type is not an array.
+func splitArrayType(typeStr string) (string, int, bool) {
+	if !strings.HasSuffix(typeStr, "]") {
+		return typeStr, 0, false
+	}
+
+	openIndex := strings.LastIndex(typeStr, "[")
+	if openIndex == -1 {
+		return typeStr, 0, false
+	}
+
+	lengthStr := typeStr[openIndex+1 : len(typeStr)-1]
+	if lengthStr == "" {
+		return typeStr[:openIndex], -1, true
+	}
+
+	length, err := strconv.Atoi(lengthStr)
+	if err != nil || length < 0 {
+		return typeStr, 0, false
+	}
+
+	return typeStr[:openIndex], length, true
+}
@@ -0,0 +1,202 @@
+package eip712_test
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi/eip712"
+)
+
+// mailTypedData is the example from the EIP-712 specification.
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+// groupTypedData extends the EIP-712 example with arrays of structs
+// and arrays of addresses.
+const groupTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Group": [
+			{"name": "name", "type": "string"},
+			{"name": "members", "type": "Person[]"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person[]"},
+			{"name": "contents", "type": "string"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallets", "type": "address[]"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {
+			"name": "Cow",
+			"wallets": ["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF"]
+		},
+		"to": [{
+			"name": "Bob",
+			"wallets": [
+				"0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+				"0xB0BdaBea57B0BDABeA57b0bdABEA57b0BDabEa57",
+				"0xB0B0b0b0b0b0B000000000000000000000000000"
+			]
+		}],
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func ExampleParseTypedData() {
+	typedData, err := eip712.ParseTypedData([]byte(mailTypedData))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	encodedType, err := typedData.EncodeType("Mail")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	typeHash, err := typedData.TypeHash("Mail")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	domainSeparator, err := typedData.DomainSeparator()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	digest, err := typedData.Hash()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(encodedType)
+	fmt.Println(common.Bytes2Hex(typeHash))
+	fmt.Println(common.Bytes2Hex(domainSeparator))
+	fmt.Println(common.Bytes2Hex(messageHash))
+	fmt.Println(common.Bytes2Hex(digest))
+
+	// Output:
+	// Mail(Person from,Person to,string contents)Person(string name,address wallet)
+	// a0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2
+	// f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f
+	// c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e
+	// be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2
+}
+
+func ExampleTypedData_Hash() {
+	typedData, err := eip712.ParseTypedData([]byte(groupTypedData))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	encodedType, err := typedData.EncodeType("Group")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	digest, err := typedData.Hash()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(encodedType)
+	fmt.Println(common.Bytes2Hex(digest))
+
+	// Output:
+	// Group(string name,Person[] members)Person(string name,address[] wallets)
+	// a85c2e2b118698e88db68a8105b794a8cc7cec074e89ef991cb4f5f533819cc2
+}
+
+func ExampleTypedData_DomainSeparator() {
+	typedData := &eip712.TypedData{
+		Domain: map[string]any{
+			"name":              "USD Coin",
+			"version":           "2",
+			"chainId":           "0x1",
+			"verifyingContract": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		},
+	}
+
+	domainSeparator, err := typedData.DomainSeparator()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(common.Bytes2Hex(domainSeparator))
+
+	// Output: 06c37168a7db5138defc7866392bb87a741f9b3d104deb5094588ce041cae335
+}
+
+func ExampleTypedData_DomainSeparator_integerStrings() {
+	for _, chainID := range []string{"10", "010", "0xa", "1_0", "0b1010", "0o12"} {
+		typedData := &eip712.TypedData{
+			Domain: map[string]any{"name": "USD Coin", "chainId": chainID},
+		}
+
+		domainSeparator, err := typedData.DomainSeparator()
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		fmt.Println(chainID, common.Bytes2Hex(domainSeparator))
+	}
+
+	// Output:
+	// 10 c8f878ffdf2e7589f87fe6326dd23f33b01b054a54275bf5dcce28299d4e1f08
+	// 010 c8f878ffdf2e7589f87fe6326dd23f33b01b054a54275bf5dcce28299d4e1f08
+	// 0xa c8f878ffdf2e7589f87fe6326dd23f33b01b054a54275bf5dcce28299d4e1f08
+	// EIP712Domain.chainId: invalid integer: "1_0"
+	// EIP712Domain.chainId: invalid integer: "0b1010"
+	// EIP712Domain.chainId: invalid integer: "0o12"
+}
@@ -1,10 +1,14 @@
 package abi
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"math/big"
+	"reflect"
 	"strconv"
-	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -64,11 +68,29 @@ func EncodeWithSignature(funcSignature string, params ...any) ([]byte, error) {
 
 }
 
-// EncodeSignature encodes signature to 4-byte selector.
+// EncodeSignature encodes signature to 4-byte selector. Output
+// parameters, if any (i.e. `balanceOf(address)(uint256)`), are not
+// part of the selector.
 func EncodeSignature(funcSignature string) []byte {
+	if inputSig, _, _, err := splitSignature(funcSignature); err == nil {
+		funcSignature = inputSig
+	}
+
 	return crypto.Keccak256([]byte(funcSignature))[:4]
 }
 
+// EncodeReturn encodes the values returned by a function based on
+// its signature with outputs, i.e. `balanceOf(address)(uint256)`.
+// The result is the data an `eth_call` to the function would return.
+func EncodeReturn(funcSignature string, values ...any) ([]byte, error) {
+	outputTypes, err := GetSigOutputTypes(funcSignature)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return Encode(outputTypes, values...)
+}
+
 // Encode encodes given arguments based on provided types.
 func Encode(typeStrs []string, values ...any) ([]byte, error) {
 	if len(typeStrs) != len(values) {
@@ -81,79 +103,64 @@ func Encode(typeStrs []string, values ...any) ([]byte, error) {
 		)
 	}
 
-	var rawHeadChunks [][]byte
-	var tailChunks [][]byte
-	for i, typeStr := range typeStrs {
-		var encoded []byte
-
-		isTypeTuple, splitedTypes, err := IsTuple(typeStr)
-		if err != nil {
-			return []byte{}, err
-		}
-
-		isTypeArray, arraySize, err := IsArray(typeStr)
-		if err != nil {
-			return []byte{}, err
-		}
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return []byte{}, err
+	}
 
-		if isTypeArray {
-			if arraySize != 0 && len(values[i].([]any)) != arraySize {
-				return nil, fmt.Errorf("array size mismatch")
-			}
-			openBracketIndex := strings.LastIndex(typeStr, "[")
+	return encodeTuple(types, values)
+}
 
-			var arrayTypes []string
-			var arrayValues []any
-			arrayValues, ok := values[i].([]any)
-			if !ok {
-				arrayValues = toAnyArray(values[i])
-			}
-			for j := 0; j < len(arrayValues); j++ {
-				arrayTypes = append(arrayTypes, typeStr[:openBracketIndex])
-			}
+// AppendEncode appends the encoding of given arguments based on
+// provided types to dst and returns the extended buffer, i.e. to reuse
+// a buffer across encodings. The encoded size is computed first, so
+// that dst grows at most once, and values are written in place.
+func AppendEncode(dst []byte, typeStrs []string, values ...any) ([]byte, error) {
+	if len(typeStrs) != len(values) {
+		return dst, fmt.Errorf(
+			"typeStrs and values must have the same length. typeStrs: %v (length %v), values: %v (length %v)",
+			typeStrs,
+			len(typeStrs),
+			values,
+			len(values),
+		)
+	}
 
-			encoded, err = Encode(arrayTypes, arrayValues...)
-			if err != nil {
-				return []byte{}, err
-			}
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return dst, err
+	}
 
-			arraySize := big.NewInt(int64(len(arrayValues)))
-			encoded = append(common.LeftPadBytes(arraySize.Bytes(), 32), encoded...)
-		} else if isTypeTuple {
-			encoded, err = Encode(splitedTypes, values[i].([]any)...)
-			if err != nil {
-				return []byte{}, err
-			}
-		} else {
-			encoded, err = encode(typeStr, values[i])
-			if err != nil {
-				return []byte{}, err
-			}
-		}
+	return appendTuple(dst, types, values)
+}
 
-		if !IsDynamic(typeStr, isTypeTuple) {
-			rawHeadChunks = append(rawHeadChunks, encoded)
-			tailChunks = append(tailChunks, nil)
-		} else {
-			rawHeadChunks = append(rawHeadChunks, nil)
-			tailChunks = append(tailChunks, encoded)
-		}
-	}
+// EncodeTo encodes given arguments based on provided types and writes
+// the encoding to w in a single call, using a pooled buffer. Returns
+// the number of bytes written.
+func EncodeTo(w io.Writer, typeStrs []string, values ...any) (int, error) {
+	buf := encodeBufferPool.Get().(*[]byte)
+	defer encodeBufferPool.Put(buf)
 
-	headLength := calculateHeadLength(rawHeadChunks)
-	tailOffsets := calculateTailOffsets(tailChunks)
-	headChunks, err := buildHeadChunks(rawHeadChunks, tailOffsets, headLength)
+	encoded, err := AppendEncode((*buf)[:0], typeStrs, values...)
+	*buf = encoded
 	if err != nil {
-		return []byte{}, err
+		return 0, err
 	}
 
-	final := joinChunks(headChunks, tailChunks)
+	return w.Write(encoded)
+}
 
-	return final, nil
+// encodeBufferPool holds the buffers used by EncodeTo.
+var encodeBufferPool = sync.Pool{
+	New: func() any {
+		return new([]byte)
+	},
 }
 
 // EncodePacked encodes given arguments based on provided types
-// with packed encoding.
+// with packed encoding, like Solidity abi.encodePacked: elementary
+// values are not padded, except array elements which are padded to
+// 32 bytes.
 func EncodePacked(typeStrs []string, values ...any) ([]byte, error) {
 	if len(typeStrs) != len(values) {
 		return []byte{}, fmt.Errorf("typeStrs and values must have the same length. typeStrs: %v (length %v), values: %v (length %v)",
@@ -164,346 +171,525 @@ func EncodePacked(typeStrs []string, values ...any) ([]byte, error) {
 		)
 	}
 
-	var result []byte
-	for i, typeStr := range typeStrs {
-		var encoded []byte
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return []byte{}, err
+	}
 
-		isTypeTuple, splitedTypes, err := IsTuple(typeStr)
+	var result []byte
+	for i, t := range types {
+		encoded, err := encodePackedValue(t, values[i])
 		if err != nil {
 			return []byte{}, err
 		}
 
-		isTypeArray, arraySize, err := IsArray(typeStr)
-		if err != nil {
-			return []byte{}, err
-		}
+		result = append(result, encoded...)
+	}
 
-		if isTypeArray {
-			if arraySize != 0 && len(values[i].([]any)) != arraySize {
-				return nil, fmt.Errorf("array size mismatch")
-			}
-			openBracketIndex := strings.LastIndex(typeStr, "[")
+	return result, nil
+}
 
-			var arrayTypes []string
-			arrayValues := values[i].([]any)
-			for j := 0; j < len(arrayValues); j++ {
-				arrayTypes = append(arrayTypes, typeStr[:openBracketIndex])
-			}
+// encodeTuple encodes given values as the components of a tuple.
+// Calls appendTuple function.
+func encodeTuple(types []*Type, values []any) ([]byte, error) {
+	encoded, err := appendTuple(nil, types, values)
+	if err != nil {
+		return []byte{}, err
+	}
 
-			encoded, err = EncodePacked(arrayTypes, arrayValues...)
-			if err != nil {
-				return []byte{}, err
-			}
-		} else if isTypeTuple {
-			encoded, err = EncodePacked(splitedTypes, values[i].([]any)...)
-			if err != nil {
-				return []byte{}, err
-			}
-		} else {
-			encoded, err = encodePacked(typeStr, values[i])
+	return encoded, nil
+}
+
+// appendTuple appends the encoding of given values as the components
+// of a tuple to dst. The encoded size is computed first, then values
+// are written in place, so that dst grows at most once.
+func appendTuple(dst []byte, types []*Type, values []any) ([]byte, error) {
+	size, err := tupleSize(types, values)
+	if err != nil {
+		return dst, err
+	}
+
+	start := len(dst)
+	dst = append(dst, make([]byte, size)...)
+	if _, err := writeTuple(dst[start:], types, values); err != nil {
+		return dst[:start], err
+	}
+
+	return dst, nil
+}
+
+// tupleSize returns the byte size of the encoding of given values as
+// the components of a tuple.
+func tupleSize(types []*Type, values []any) (int, error) {
+	if len(types) != len(values) {
+		return 0, fmt.Errorf("number of types and values mismatch: %v types, %v values", len(types), len(values))
+	}
+
+	size := 0
+	for i, t := range types {
+		size += t.headSize()
+		if t.IsDynamic() {
+			tailSize, err := dynamicSize(t, values[i])
 			if err != nil {
-				return []byte{}, err
+				return 0, err
 			}
+			size += tailSize
 		}
-
-		result = append(result, encoded...)
 	}
 
-	return result, nil
+	return size, nil
 }
 
-// encode encodes given argument based on provided type string.
-func encode(typeStr string, value any) ([]byte, error) {
-	encoded, err := encodePacked(typeStr, value)
-	if err != nil {
-		return []byte{}, err
+// dynamicSize returns the byte size of the tail encoding of given
+// value of dynamic type.
+func dynamicSize(t *Type, value any) (int, error) {
+	switch t.Kind {
+	case StringKind:
+		val, ok := value.(string)
+		if !ok {
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
+		return 32 + paddedSize(len(val)), nil
+	case BytesKind:
+		val, ok := value.([]byte)
+		if !ok {
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
+		return 32 + paddedSize(len(val)), nil
+	case ArrayKind, SliceKind:
+		arrayValues, err := toArrayValues(t, value)
+		if err != nil {
+			return 0, err
+		}
+
+		size, err := tupleSize(repeatType(t.Elem, len(arrayValues)), arrayValues)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Kind == SliceKind {
+			size += 32
+		}
+		return size, nil
+	case TupleKind:
+		tupleValues, ok := value.([]any)
+		if !ok {
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
+		return tupleSize(t.Components, tupleValues)
+	default:
+		return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
 	}
+}
 
-	if typeStr == "string" || typeStr == "bytes" {
-		bytesLengthBigInt := big.NewInt(int64(len(encoded)))
-		bytesLength := common.LeftPadBytes(bytesLengthBigInt.Bytes(), 32)
+// writeTuple writes the encoding of given values as the components of
+// a tuple to buf, placing static values in the head and dynamic values
+// in the tail. Returns the number of bytes written. buf must be large
+// enough, as computed by tupleSize.
+func writeTuple(buf []byte, types []*Type, values []any) (int, error) {
+	if len(types) != len(values) {
+		return 0, fmt.Errorf("number of types and values mismatch: %v types, %v values", len(types), len(values))
+	}
 
-		for len(encoded)%32 != 0 {
-			encoded = append(encoded, 0x0)
+	head := 0
+	tail := 0
+	for _, t := range types {
+		tail += t.headSize()
+	}
+
+	for i, t := range types {
+		if !t.IsDynamic() {
+			n, err := writeValue(buf[head:], t, values[i])
+			if err != nil {
+				return 0, err
+			}
+			head += n
+			continue
 		}
 
-		encoded = append(bytesLength, encoded...)
+		putSize(buf[head:], tail)
+		head += 32
 
-	} else if len(typeStr) > 5 && typeStr[:5] == "bytes" {
-		encoded = common.RightPadBytes(encoded[:], 32)
-	} else {
-		encoded = common.LeftPadBytes(encoded[:], 32)
+		n, err := writeValue(buf[tail:], t, values[i])
+		if err != nil {
+			return 0, err
+		}
+		tail += n
 	}
 
-	return encoded, nil
+	return tail, nil
 }
 
-// encodePacked encodes given argument based on provided type string
-// with packed encoding.
-func encodePacked(typeStr string, value any) ([]byte, error) {
+// writeValue writes the encoding of given value based on provided type
+// to buf. Dynamic length arrays are prefixed with their length.
+// Returns the number of bytes written.
+func writeValue(buf []byte, t *Type, value any) (int, error) {
+	switch t.Kind {
+	case ArrayKind, SliceKind:
+		arrayValues, err := toArrayValues(t, value)
+		if err != nil {
+			return 0, err
+		}
 
-	bytes := make([]byte, 0)
-	switch typeStr {
-	case "address":
-		val, ok := value.(*common.Address)
+		offset := 0
+		if t.Kind == SliceKind {
+			putSize(buf, len(arrayValues))
+			offset = 32
+		}
+
+		n, err := writeTuple(buf[offset:], repeatType(t.Elem, len(arrayValues)), arrayValues)
+		return offset + n, err
+	case TupleKind:
+		tupleValues, ok := value.([]any)
 		if !ok {
-			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", typeStr, value)
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
 		}
-		bytes = append(bytes, val[:]...)
 
-	case "bool":
+		return writeTuple(buf, t.Components, tupleValues)
+	default:
+		return writeElementary(buf, t, value)
+	}
+}
+
+// writeElementary writes the encoding of given value based on provided
+// elementary type to buf. Returns the number of bytes written.
+func writeElementary(buf []byte, t *Type, value any) (int, error) {
+	switch t.Kind {
+	case AddressKind:
+		switch val := value.(type) {
+		case *common.Address:
+			copy(buf[12:32], val[:])
+		case common.Address:
+			copy(buf[12:32], val[:])
+		default:
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
+	case BoolKind:
 		val, ok := value.(bool)
 		if !ok {
-			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", typeStr, value)
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
 		}
 		if val {
-			bytes = append(bytes, []byte{0x1}...)
-		} else {
-			bytes = append(bytes, []byte{0x0}...)
+			buf[31] = 1
 		}
-	case "string":
-		val, ok := value.(string)
+	case IntKind, UintKind:
+		val, ok := value.(*big.Int)
 		if !ok {
-			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", typeStr, value)
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
 		}
-		bytes = append(bytes, []byte(val)...)
-	default:
-		if typeStr[:3] == "int" || typeStr[:4] == "uint" {
-			val, ok := value.(*big.Int)
-			if !ok {
-				return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", typeStr, value)
-			}
 
-			var index int
-			if typeStr[:3] == "int" {
-				index = 3
-				if len(typeStr) == 3 {
-					typeStr += "256"
-				}
-			} else {
-				index = 4
-				if len(typeStr) == 4 {
-					typeStr += "256"
-				}
-			}
-			bits, err := strconv.Atoi(typeStr[index:])
-			if err != nil {
-				return []byte{}, fmt.Errorf("error getting bits from %s: %v", typeStr, err)
-			}
-			if bits%8 != 0 {
-				return []byte{}, fmt.Errorf("invalid bits value: %v, bits = %v", typeStr, bits)
-			}
+		if err := checkIntegerRange(t.Kind == IntKind, t.Size, val); err != nil {
+			return 0, fmt.Errorf("value out of allowed range: %v, %v", t, val)
+		}
 
-			if val.Cmp(validCoreTypes[typeStr].Max) == 1 || val.Cmp(validCoreTypes[typeStr].Min) == -1 {
-				return []byte{}, fmt.Errorf("value out of allowed range: %v, %v", typeStr, val)
-			}
+		if val.Sign() == -1 {
+			// two's complement, sign extended to 256 bits
+			new(big.Int).Add(twoTo256, val).FillBytes(buf[:32])
+		} else {
+			val.FillBytes(buf[:32])
+		}
+	case FixedBytesKind:
+		val, ok := value.([]byte)
+		if !ok {
+			val, ok = byteArrayToSlice(value)
+		}
+		if !ok {
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
 
-			if typeStr[:3] == "int" && val.Sign() == -1 {
-				absVal := new(big.Int).Abs(val)
-				absVal.Sub(absVal, big.NewInt(1))
+		if len(val) > t.Size {
+			return 0, fmt.Errorf("value and type bytes size mismatch: type %v; value bytes size %v", t, len(val))
+		}
 
-				// Create a mask of all 1s for the specific bit size
-				mask := new(big.Int).Lsh(big.NewInt(1), uint(bits))
-				mask.Sub(mask, big.NewInt(1))
+		copy(buf, val)
+	case StringKind:
+		val, ok := value.(string)
+		if !ok {
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
 
-				// NOT operation (flip all bits)
-				absVal.Xor(absVal, mask)
+		putSize(buf, len(val))
+		copy(buf[32:], val)
+		return 32 + paddedSize(len(val)), nil
+	case BytesKind:
+		val, ok := value.([]byte)
+		if !ok {
+			return 0, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
 
-				// Trim to correct number of bytes
-				return common.RightPadBytes(absVal.Bytes(), bits/8), nil
-			}
+		putSize(buf, len(val))
+		copy(buf[32:], val)
+		return 32 + paddedSize(len(val)), nil
+	default:
+		encoded, err := encode(t, value)
+		if err != nil {
+			return 0, err
+		}
 
-			bytes = append(bytes, common.LeftPadBytes(val.Bytes(), bits/8)...)
+		copy(buf, encoded)
+	}
 
-		} else if typeStr[:5] == "bytes" {
-			val, ok := value.([]byte)
-			if !ok {
-				return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", typeStr, value)
-			}
+	return 32, nil
+}
+
+// toArrayValues returns the elements of given array value, checking
+// the length of fixed size arrays.
+func toArrayValues(t *Type, value any) ([]any, error) {
+	arrayValues, ok := value.([]any)
+	if !ok {
+		arrayValues, ok = toAnyArray(value)
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+	}
 
-			if len(typeStr) > 5 {
-				bytesSize, err := strconv.Atoi(typeStr[5:])
-				if err != nil {
-					return []byte{}, fmt.Errorf("error getting bytes size: %v", typeStr)
-				}
+	if t.Kind == ArrayKind && len(arrayValues) != t.Length {
+		return nil, fmt.Errorf("array size mismatch")
+	}
 
-				if bytesSize < 1 || bytesSize > 32 {
-					return []byte{}, fmt.Errorf("invalid byte size: %v", typeStr)
-				}
+	return arrayValues, nil
+}
 
-				if len(val) > bytesSize {
-					return []byte{}, fmt.Errorf("value and type bytes size mismatch: type %v; value bytes size %v", typeStr, len(val))
-				}
+// putSize writes an offset or length as a 32-byte word to buf.
+func putSize(buf []byte, size int) {
+	binary.BigEndian.PutUint64(buf[24:32], uint64(size))
+}
 
-				for len(val)%bytesSize != 0 {
-					val = append(val, 0x0)
-				}
-			}
+// paddedSize returns given byte size rounded up to a multiple of 32.
+func paddedSize(size int) int {
+	return (size + 31) / 32 * 32
+}
 
-			bytes = append(bytes, val...)
-		} else if typeStr[:5] == "fixed" || typeStr[:6] == "ufixed" { // @note differences in result with fixed/ufixed types
-			val, ok := value.(*big.Float)
-			if !ok {
-				return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", typeStr, value)
-			}
+// encodePackedValue encodes given value based on provided type
+// with packed encoding.
+func encodePackedValue(t *Type, value any) ([]byte, error) {
+	switch t.Kind {
+	case ArrayKind, SliceKind:
+		arrayValues, err := toArrayValues(t, value)
+		if err != nil {
+			return []byte{}, err
+		}
 
-			var index int
-			if typeStr[:5] == "fixed" {
-				index = 5
-				if len(typeStr) == 5 {
-					typeStr += "128x18"
-				}
+		var result []byte
+		for _, arrayValue := range arrayValues {
+			var encoded []byte
+			if t.Elem.isElementary() && !t.Elem.IsDynamic() {
+				// array elements are padded to 32 bytes
+				encoded, err = encode(t.Elem, arrayValue)
 			} else {
-				index = 6
-				if len(typeStr) == 6 {
-					typeStr += "128x18"
-				}
+				encoded, err = encodePackedValue(t.Elem, arrayValue)
 			}
-
-			sizes := strings.Split(typeStr[index:], "x")
-			bits, err := strconv.Atoi(sizes[0])
 			if err != nil {
-				return []byte{}, fmt.Errorf("error getting bits from %s: %v", typeStr, err)
+				return []byte{}, err
 			}
+			result = append(result, encoded...)
+		}
 
-			fracPlaces, err := strconv.Atoi(sizes[1])
-			if err != nil {
-				return []byte{}, fmt.Errorf("error getting frac. places from %s: %v", typeStr, err)
-			}
+		return result, nil
+	case TupleKind:
+		tupleValues, ok := value.([]any)
+		if !ok {
+			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
 
-			if bits%8 != 0 {
-				return []byte{}, fmt.Errorf("invalid bits value: %v, bits = %v", typeStr, bits)
-			}
+		if len(tupleValues) != len(t.Components) {
+			return []byte{}, fmt.Errorf("number of types and values mismatch: %v types, %v values", len(t.Components), len(tupleValues))
+		}
 
-			var min *big.Float
-			var max *big.Float
-			if typeStr[:5] == "fixed" {
-				min, max = computeSignedFixedBounds(int64(bits), float64(fracPlaces))
-			} else {
-				min, max = computeUnsignedFixedBounds(int64(bits), float64(fracPlaces))
+		var result []byte
+		for i, component := range t.Components {
+			encoded, err := encodePackedValue(component, tupleValues[i])
+			if err != nil {
+				return []byte{}, err
 			}
+			result = append(result, encoded...)
+		}
 
-			if val.Cmp(min) == -1 || val.Cmp(max) == 1 {
-				return []byte{}, fmt.Errorf("value out of allowed range: %v, %v", typeStr, val)
-			}
+		return result, nil
+	default:
+		return encodePacked(t, value)
+	}
+}
+
+// encode encodes given argument based on provided elementary type.
+func encode(t *Type, value any) ([]byte, error) {
+	encoded, err := encodePacked(t, value)
+	if err != nil {
+		return []byte{}, err
+	}
 
-			scaledValue := new(big.Float)
-			scaledValue.Mul(val, pow(floatTen, uint64(fracPlaces)))
-			bigIntValue := new(big.Int)
-			scaledValue.Int(bigIntValue)
+	switch t.Kind {
+	case StringKind, BytesKind:
+		bytesLengthBigInt := big.NewInt(int64(len(encoded)))
+		bytesLength := common.LeftPadBytes(bytesLengthBigInt.Bytes(), 32)
 
-			bytes = append(bytes, common.LeftPadBytes(bigIntValue.Bytes(), bits/8)...)
+		for len(encoded)%32 != 0 {
+			encoded = append(encoded, 0x0)
+		}
 
+		encoded = append(bytesLength, encoded...)
+	case FixedBytesKind:
+		encoded = common.RightPadBytes(encoded, 32)
+	case IntKind, FixedKind:
+		// sign extension
+		if encoded[0]&0x80 != 0 {
+			encoded = append(bytes.Repeat([]byte{0xff}, 32-len(encoded)), encoded...)
 		} else {
-			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", typeStr, value)
+			encoded = common.LeftPadBytes(encoded, 32)
 		}
+	default:
+		encoded = common.LeftPadBytes(encoded, 32)
 	}
 
-	return bytes, nil
+	return encoded, nil
 }
 
-// calculateHeadLength calculates encoded bytecode head length.
-func calculateHeadLength(rawHeadChunks [][]byte) uint64 {
-	headLength := uint64(0)
-	for _, chunk := range rawHeadChunks {
-		if chunk != nil {
-			headLength += uint64(len(chunk))
+// encodePacked encodes given argument based on provided elementary
+// type with packed encoding.
+func encodePacked(t *Type, value any) ([]byte, error) {
+
+	bytes := make([]byte, 0)
+	switch t.Kind {
+	case AddressKind:
+		switch val := value.(type) {
+		case *common.Address:
+			bytes = append(bytes, val[:]...)
+		case common.Address:
+			bytes = append(bytes, val[:]...)
+		default:
+			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
+
+	case BoolKind:
+		val, ok := value.(bool)
+		if !ok {
+			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
+		if val {
+			bytes = append(bytes, []byte{0x1}...)
 		} else {
-			headLength += 32
+			bytes = append(bytes, []byte{0x0}...)
+		}
+	case StringKind:
+		val, ok := value.(string)
+		if !ok {
+			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
+		bytes = append(bytes, []byte(val)...)
+	case IntKind, UintKind:
+		val, ok := value.(*big.Int)
+		if !ok {
+			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", t, value)
 		}
-	}
 
-	return headLength
-}
+		encoded, err := encodeInteger(t.Kind == IntKind, t.Size, val)
+		if err != nil {
+			return []byte{}, fmt.Errorf("value out of allowed range: %v, %v", t, val)
+		}
 
-// calculateTailOffsets calculates encoded bytecode tail offsets.
-func calculateTailOffsets(tailChunks [][]byte) []uint64 {
+		bytes = append(bytes, encoded...)
+	case FixedBytesKind, BytesKind:
+		val, ok := value.([]byte)
+		if !ok && t.Kind == FixedBytesKind {
+			val, ok = byteArrayToSlice(value)
+		}
+		if !ok {
+			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", t, value)
+		}
 
-	tailOffsets := []uint64{0}
-	accSum := uint64(0)
-	for i := 0; i < len(tailChunks)-1; i++ {
-		accSum += uint64(len(tailChunks[i]))
-		tailOffsets = append(tailOffsets, accSum)
-	}
+		if t.Kind == FixedBytesKind {
+			if len(val) > t.Size {
+				return []byte{}, fmt.Errorf("value and type bytes size mismatch: type %v; value bytes size %v", t, len(val))
+			}
 
-	return tailOffsets
-}
+			val = common.RightPadBytes(val, t.Size)
+		}
 
-// buildHeadChunks builds the head chunk for the encoded bytecode.
-func buildHeadChunks(rawHeadChunks [][]byte, tailOffsets []uint64, headLength uint64) ([][]byte, error) {
-	if len(rawHeadChunks) != len(tailOffsets) {
-		return nil, fmt.Errorf("tailOffsets and rawHeadChunks must be the same length")
-	}
+		bytes = append(bytes, val...)
+	case FixedKind, UfixedKind:
+		val, err := toDecimal(value, t.Decimals)
+		if err != nil {
+			return []byte{}, fmt.Errorf("invalid parameter type: %v, %T: %v", t, value, err)
+		}
 
-	var headChunks [][]byte
-	for i, chunk := range rawHeadChunks {
-		if chunk != nil {
-			headChunks = append(headChunks, chunk)
-		} else {
-			headChunks = append(headChunks, encodeUint256(big.NewInt(int64(headLength+tailOffsets[i]))))
+		encoded, err := encodeInteger(t.Kind == FixedKind, t.Size, val.Value)
+		if err != nil {
+			return []byte{}, fmt.Errorf("value out of allowed range: %v, %v", t, val)
 		}
+
+		bytes = append(bytes, encoded...)
+	default:
+		return []byte{}, fmt.Errorf("invalid parameter type: %v, %T", t, value)
 	}
 
-	return headChunks, nil
+	return bytes, nil
 }
 
-// encodeUint256 encodes value specifically to `uint256`.
-func encodeUint256(value *big.Int) []byte {
-	return common.LeftPadBytes(value.Bytes(), 32)
-}
+// encodeInteger encodes signed or unsigned integer value within
+// given bit size, using two's complement for negative values.
+// Returns an error if the value is out of range.
+func encodeInteger(signed bool, bits int, val *big.Int) ([]byte, error) {
+	if err := checkIntegerRange(signed, bits, val); err != nil {
+		return nil, err
+	}
+
+	if val.Sign() == -1 {
+		// two's complement within the type bit size
+		twos := new(big.Int).Lsh(one, uint(bits))
+		twos.Add(twos, val)
+
+		return common.LeftPadBytes(twos.Bytes(), bits/8), nil
+	}
 
-// joinChunks joins array of byte arrays.
-func joinChunks(headChunks [][]byte, tailChunks [][]byte) []byte {
+	return common.LeftPadBytes(val.Bytes(), bits/8), nil
+}
 
-	joined := make([]byte, 0)
+// checkIntegerRange checks whether signed or unsigned integer value
+// fits in given bit size, based on validCoreTypes bounds.
+func checkIntegerRange(signed bool, bits int, val *big.Int) error {
+	// fast path for values far within bounds, i.e. |val| < 2**(bits-2)
+	if val.BitLen() < bits-1 && (signed || val.Sign() >= 0) {
+		return nil
+	}
 
-	for _, chunk := range headChunks {
-		joined = append(joined, chunk...)
+	typeStr := "uint" + strconv.Itoa(bits)
+	if signed {
+		typeStr = "int" + strconv.Itoa(bits)
 	}
 
-	for _, chunk := range tailChunks {
-		joined = append(joined, chunk...)
+	if val.Cmp(validCoreTypes[typeStr].Max) == 1 || val.Cmp(validCoreTypes[typeStr].Min) == -1 {
+		return fmt.Errorf("value out of allowed range: %v, %v", typeStr, val)
 	}
 
-	return joined
+	return nil
 }
 
-// pow performs exponentiation for big.Float number.
-func pow(a *big.Float, e uint64) *big.Float {
-	result := zeroFloat().Copy(a)
-	for i := uint64(0); i < e-1; i++ {
-		result = mul(result, a)
+// byteArrayToSlice converts a byte array of any length (i.e. [4]byte
+// or common.Hash) to a byte slice.
+func byteArrayToSlice(value any) ([]byte, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Array || v.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
 	}
-	return result
-}
 
-// zeroFloat returns 0 in big.Float type.
-func zeroFloat() *big.Float {
-	r := big.NewFloat(0.0)
-	r.SetPrec(256)
-	return r
-}
+	val := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(val), v)
 
-// mul multiplies two big.Float arguments
-func mul(a, b *big.Float) *big.Float {
-	return zeroFloat().Mul(a, b)
+	return val, true
 }
 
-func toAnyArray(input any) []any {
-	var result []interface{}
+// toAnyArray converts a slice or array of any element type (i.e.
+// []*big.Int, [][]byte or [2]common.Address) to a slice of values.
+func toAnyArray(input any) ([]any, bool) {
+	v := reflect.ValueOf(input)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
 
-	switch v := input.(type) {
-	case []string:
-		for _, s := range v {
-			result = append(result, s)
-		}
-	case [][]byte:
-		for _, b := range v {
-			result = append(result, b)
-		}
-	default:
-		fmt.Println("Unsupported input type")
+	result := make([]any, v.Len())
+	for i := range result {
+		result[i] = v.Index(i).Interface()
 	}
 
-	return result
+	return result, true
 }
@@ -1,8 +1,11 @@
 package abi_test
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"math/big"
+	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/omnes-tech/abi"
@@ -48,6 +51,22 @@ func ExampleEncode_second() {
 	// Output: 00000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000002626c61626c61626c610000000000000000000000000000000000000000000000626c61626c61626c613200000000000000000000000000000000000000000000
 }
 
+func ExampleEncode_typedSlices() {
+	encoded, err := abi.Encode(
+		[]string{"address[]", "uint256[2][]"},
+		[]common.Address{common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")},
+		[][2]*big.Int{{big.NewInt(1), big.NewInt(2)}},
+	)
+
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(common.Bytes2Hex(encoded))
+
+	// Output: 0000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000010000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d2789000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002
+}
+
 func ExampleEncodePacked() {
 	addressParam := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
 	uint256ArrParam := []any{big.NewInt(100), big.NewInt(352)}
@@ -128,3 +147,122 @@ func ExampleEncodeSignature() {
 
 	// Output: c6210dba
 }
+
+func ExampleEncodeReturn() {
+	encoded, err := abi.EncodeReturn(
+		"getReserves()(uint112,uint112,uint32)",
+		big.NewInt(1000),
+		big.NewInt(2000),
+		big.NewInt(1700000000),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(common.Bytes2Hex(encoded))
+
+	// Output: 00000000000000000000000000000000000000000000000000000000000003e800000000000000000000000000000000000000000000000000000000000007d0000000000000000000000000000000000000000000000000000000006553f100
+}
+
+func ExampleAppendEncode() {
+	buf := make([]byte, 0, 1024)
+	for _, amount := range []int64{1000, 2000} {
+		encoded, err := abi.AppendEncode(
+			buf[:0],
+			[]string{"address", "uint256"},
+			common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"),
+			big.NewInt(amount),
+		)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		fmt.Println(common.Bytes2Hex(encoded))
+	}
+
+	// Output:
+	// 0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d278900000000000000000000000000000000000000000000000000000000000003e8
+	// 0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d278900000000000000000000000000000000000000000000000000000000000007d0
+}
+
+func ExampleEncodeTo() {
+	var buf bytes.Buffer
+	n, err := abi.EncodeTo(&buf, []string{"string", "int8"}, "hello", big.NewInt(-1))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(n)
+	fmt.Println(common.Bytes2Hex(buf.Bytes()))
+
+	// Output:
+	// 128
+	// 0000000000000000000000000000000000000000000000000000000000000040ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff000000000000000000000000000000000000000000000000000000000000000568656c6c6f000000000000000000000000000000000000000000000000000000
+}
+
+// aggregate3Batch returns a Multicall3 aggregate3 batch of n calls.
+func aggregate3Batch(n int) []any {
+	target := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	callData := common.FromHex("0x70a082310000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d2789")
+
+	batch := make([]any, n)
+	for i := range batch {
+		batch[i] = []any{target, i%2 == 0, callData}
+	}
+
+	return batch
+}
+
+func BenchmarkEncode(b *testing.B) {
+	typeStrs := []string{"(address,bool,bytes)[]"}
+	batch := aggregate3Batch(1000)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := abi.Encode(typeStrs, batch); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncode_uint256Array(b *testing.B) {
+	typeStrs := []string{"uint256[]"}
+	values := make([]any, 10000)
+	for i := range values {
+		values[i] = big.NewInt(int64(i) * 1e9)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := abi.Encode(typeStrs, values); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAppendEncode(b *testing.B) {
+	typeStrs := []string{"(address,bool,bytes)[]"}
+	batch := aggregate3Batch(1000)
+	var buf []byte
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err error
+		buf, err = abi.AppendEncode(buf[:0], typeStrs, batch)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeTo(b *testing.B) {
+	typeStrs := []string{"(address,bool,bytes)[]"}
+	batch := aggregate3Batch(1000)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := abi.EncodeTo(io.Discard, typeStrs, batch); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
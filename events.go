@@ -0,0 +1,155 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// LogValue is a decoded event parameter.
+type LogValue struct {
+	Name    string // empty when decoding from a signature without names
+	Type    string
+	Indexed bool
+	Hashed  bool // indexed strings, bytes, arrays and tuples are only available as their keccak256 hash
+	Value   any  // common.Hash when Hashed
+}
+
+// EncodeEventTopic encodes event signature to its 32-byte topic
+// (topic0), i.e. the keccak256 hash of the signature.
+func EncodeEventTopic(eventSignature string) []byte {
+	return crypto.Keccak256([]byte(eventSignature))
+}
+
+// DecodeLog decodes an event log based on given event signature.
+// indexedFlags tells which of the signature parameters are indexed.
+// topics must start with the event topic (topic0) followed by one
+// topic per indexed parameter. Values are returned in signature order.
+func DecodeLog(eventSignature string, indexedFlags []bool, topics [][]byte, data []byte) ([]LogValue, error) {
+	typeStrs, err := GetSigTypes(eventSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeLog(eventSignature, typeStrs, nil, indexedFlags, false, topics, data)
+}
+
+// DecodeAnonymousLog decodes a log emitted by an anonymous event,
+// in which topics hold only the indexed parameters.
+func DecodeAnonymousLog(eventSignature string, indexedFlags []bool, topics [][]byte, data []byte) ([]LogValue, error) {
+	typeStrs, err := GetSigTypes(eventSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeLog(eventSignature, typeStrs, nil, indexedFlags, true, topics, data)
+}
+
+// DecodeLog decodes an event log emitted by the event, naming the
+// values after the event parameters.
+func (e *Event) DecodeLog(topics [][]byte, data []byte) ([]LogValue, error) {
+	names := make([]string, len(e.Inputs))
+	indexedFlags := make([]bool, len(e.Inputs))
+	for i, input := range e.Inputs {
+		names[i] = input.Name
+		indexedFlags[i] = input.Indexed
+	}
+
+	return decodeLog(e.Signature, e.InputTypes(), names, indexedFlags, e.Anonymous, topics, data)
+}
+
+// decodeLog decodes indexed values from topics and the remaining
+// values from data.
+func decodeLog(
+	eventSignature string,
+	typeStrs []string,
+	names []string,
+	indexedFlags []bool,
+	anonymous bool,
+	topics [][]byte,
+	data []byte,
+) ([]LogValue, error) {
+	if len(typeStrs) != len(indexedFlags) {
+		return nil, fmt.Errorf("typeStrs and indexedFlags must have the same length. typeStrs: %v (length %v), indexedFlags: %v (length %v)",
+			typeStrs,
+			len(typeStrs),
+			indexedFlags,
+			len(indexedFlags),
+		)
+	}
+
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedTopics := 0
+	for _, indexed := range indexedFlags {
+		if indexed {
+			expectedTopics++
+		}
+	}
+	if !anonymous {
+		expectedTopics++
+	}
+
+	if len(topics) != expectedTopics {
+		return nil, fmt.Errorf("topic count mismatch for %v: expected %d topics, got %d", eventSignature, expectedTopics, len(topics))
+	}
+
+	for i, topic := range topics {
+		if len(topic) != 32 {
+			return nil, fmt.Errorf("invalid topic %d length for %v: %d bytes", i, eventSignature, len(topic))
+		}
+	}
+
+	if !anonymous {
+		topic0 := EncodeEventTopic(eventSignature)
+		if !bytes.Equal(topics[0], topic0) {
+			return nil, fmt.Errorf("topic0 mismatch for %v: expected 0x%x, got 0x%x (the log may come from another or an anonymous event)", eventSignature, topic0, topics[0])
+		}
+		topics = topics[1:]
+	}
+
+	var dataTypes []*Type
+	for i, t := range types {
+		if !indexedFlags[i] {
+			dataTypes = append(dataTypes, t)
+		}
+	}
+
+	dataValues, err := decoder{}.decodeTuple(dataTypes, data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding data for %v: %v", eventSignature, err)
+	}
+
+	result := make([]LogValue, len(types))
+	for i, t := range types {
+		result[i] = LogValue{Type: t.String(), Indexed: indexedFlags[i]}
+		if names != nil {
+			result[i].Name = names[i]
+		}
+
+		if !indexedFlags[i] {
+			result[i].Value, dataValues = dataValues[0], dataValues[1:]
+			continue
+		}
+
+		topic := topics[0]
+		topics = topics[1:]
+		if t.IsDynamic() || !t.isElementary() {
+			result[i].Hashed = true
+			result[i].Value = common.BytesToHash(topic)
+			continue
+		}
+
+		result[i].Value, _, err = decoder{}.decode(t, topic)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding topic for %v: %v", eventSignature, err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package abi_test
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleEncodeEventTopic() {
+	topic := abi.EncodeEventTopic("Transfer(address,address,uint256)")
+
+	fmt.Println(common.Bytes2Hex(topic))
+
+	// Output: ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+}
+
+func ExampleDecodeLog() {
+	topics := [][]byte{
+		common.Hex2Bytes("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+		common.Hex2Bytes("0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d2789"),
+		common.Hex2Bytes("000000000000000000000000000000000000000000000000000000000000dead"),
+	}
+	data := common.Hex2Bytes("0000000000000000000000000000000000000000000000000de0b6b3a7640000")
+
+	values, err := abi.DecodeLog(
+		"Transfer(address,address,uint256)",
+		[]bool{true, true, false},
+		topics,
+		data,
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for _, value := range values {
+		fmt.Println(value.Type, value.Indexed, value.Value)
+	}
+
+	// Output:
+	// address true 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789
+	// address true 0x000000000000000000000000000000000000dEaD
+	// uint256 false 1000000000000000000
+}
+
+func ExampleDecodeLog_hashed() {
+	topics := [][]byte{
+		abi.EncodeEventTopic("Registered(string,uint256)"),
+		common.Hex2Bytes("1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8"),
+	}
+	data := common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000001")
+
+	values, err := abi.DecodeLog("Registered(string,uint256)", []bool{true, false}, topics, data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(values[0].Hashed, values[0].Value, values[1].Value)
+
+	_, err = abi.DecodeLog("Registered(string,uint256)", []bool{true, false}, topics[:1], data)
+	fmt.Println(err)
+
+	// Output:
+	// true 0x1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8 1
+	// topic count mismatch for Registered(string,uint256): expected 2 topics, got 1
+}
+
+func ExampleEvent_DecodeLog() {
+	contract, err := abi.LoadContract([]byte(erc20Artifact))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	event, err := contract.Event("Transfer")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	values, err := event.DecodeLog(
+		[][]byte{
+			event.Topic,
+			common.Hex2Bytes("0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d2789"),
+			common.Hex2Bytes("000000000000000000000000000000000000000000000000000000000000dead"),
+		},
+		common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000064"),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for _, value := range values {
+		fmt.Println(value.Name, value.Value)
+	}
+
+	// Output:
+	// from 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789
+	// to 0x000000000000000000000000000000000000dEaD
+	// value 100
+}
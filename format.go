@@ -0,0 +1,313 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FormattedValue is a decoded value rendered for review, i.e. before
+// signing a transaction. Arrays and tuples hold their elements in
+// Components.
+type FormattedValue struct {
+	Name       string           `json:"name,omitempty"`
+	Type       string           `json:"type"`
+	Value      string           `json:"value,omitempty"`
+	Components []FormattedValue `json:"components,omitempty"`
+}
+
+// FormattedCall is a decoded function call rendered for review. It
+// is printed as an indented tree by String and as JSON by
+// encoding/json.
+type FormattedCall struct {
+	Function  string           `json:"function"`
+	Signature string           `json:"signature"`
+	Selector  string           `json:"selector"`
+	Params    []FormattedValue `json:"params"`
+}
+
+// FormatCall decodes function call data based on given signature and
+// renders it for review. Parameter names given in the signature, i.e.
+// `transfer(address to,uint256 amount)` or
+// `permit((address owner,uint256 amount) permit)`, name the values.
+func FormatCall(funcSignature string, data []byte) (*FormattedCall, error) {
+	name, t, err := parseNamedSignature(funcSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("data byte size is too short for selector. Length: %d", len(data))
+	}
+
+	signature := name + t.String()
+	if !isSelectorIsEqual(EncodeSignature(signature), data[:4]) {
+		return nil, fmt.Errorf("invalid selector")
+	}
+
+	values, err := decoder{typed: true}.decodeTuple(t.Components, data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	return formatCall(name, t, values)
+}
+
+// NewFormattedCall renders values already decoded, i.e. by
+// DecodeWithSignature, based on given signature.
+func NewFormattedCall(funcSignature string, values []any) (*FormattedCall, error) {
+	name, t, err := parseNamedSignature(funcSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != len(t.Components) {
+		return nil, fmt.Errorf("signature types and values must have the same length. types: %d, values: %d", len(t.Components), len(values))
+	}
+
+	return formatCall(name, t, values)
+}
+
+// FormatValues renders values decoded, i.e. by Decode, based on
+// given types. Values are named after their position.
+func FormatValues(typeStrs []string, values []any) ([]FormattedValue, error) {
+	if len(typeStrs) != len(values) {
+		return nil, fmt.Errorf("typeStrs and values must have the same length. typeStrs: %d, values: %d", len(typeStrs), len(values))
+	}
+
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted := make([]FormattedValue, len(values))
+	for i, t := range types {
+		formatted[i], err = formatValue(t, "["+strconv.Itoa(i)+"]", values[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return formatted, nil
+}
+
+// String renders the call as an indented tree, i.e.:
+//
+//	transfer(address,uint256) 0xa9059cbb
+//	  to (address): 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789
+//	  amount (uint256): 1000 (0x3e8)
+func (c *FormattedCall) String() string {
+	var sb strings.Builder
+	sb.WriteString(c.Signature)
+	sb.WriteString(" ")
+	sb.WriteString(c.Selector)
+	sb.WriteString("\n")
+
+	for _, param := range c.Params {
+		param.write(&sb, 1)
+	}
+
+	return sb.String()
+}
+
+// String renders the value as an indented tree.
+func (v FormattedValue) String() string {
+	var sb strings.Builder
+	v.write(&sb, 0)
+	return sb.String()
+}
+
+// write writes the value and its components with given indentation
+// level.
+func (v FormattedValue) write(sb *strings.Builder, level int) {
+	sb.WriteString(strings.Repeat("  ", level))
+	sb.WriteString(v.Name)
+	sb.WriteString(" (")
+	sb.WriteString(v.Type)
+	sb.WriteString(")")
+
+	if v.Components == nil {
+		sb.WriteString(": ")
+		sb.WriteString(v.Value)
+		if hex := integerHex(v); hex != "" {
+			sb.WriteString(" (")
+			sb.WriteString(hex)
+			sb.WriteString(")")
+		}
+	} else if len(v.Components) == 0 {
+		sb.WriteString(": []")
+	} else {
+		sb.WriteString(":")
+	}
+	sb.WriteString("\n")
+
+	for _, component := range v.Components {
+		component.write(sb, level+1)
+	}
+}
+
+// formatCall renders decoded values of given parameters tuple.
+func formatCall(name string, t *Type, values []any) (*FormattedCall, error) {
+	signature := name + t.String()
+	call := &FormattedCall{
+		Function:  name,
+		Signature: signature,
+		Selector:  "0x" + hex.EncodeToString(EncodeSignature(signature)),
+		Params:    make([]FormattedValue, len(values)),
+	}
+
+	for i, component := range t.Components {
+		param, err := formatValue(component, componentName(t, i), values[i])
+		if err != nil {
+			return nil, err
+		}
+		call.Params[i] = param
+	}
+
+	return call, nil
+}
+
+// formatValue renders a decoded value of given type.
+func formatValue(t *Type, name string, value any) (FormattedValue, error) {
+	formatted := FormattedValue{Name: name, Type: t.String()}
+
+	switch t.Kind {
+	case ArrayKind, SliceKind, TupleKind:
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return FormattedValue{}, fmt.Errorf("%v: invalid value type for %v: %T", name, t, value)
+		}
+
+		if t.Kind == TupleKind && v.Len() != len(t.Components) {
+			return FormattedValue{}, fmt.Errorf("%v: tuple size mismatch: expected %d, got %d", name, len(t.Components), v.Len())
+		}
+
+		formatted.Components = make([]FormattedValue, v.Len())
+		for i := range formatted.Components {
+			elem, elemName := t.Elem, "["+strconv.Itoa(i)+"]"
+			if t.Kind == TupleKind {
+				elem, elemName = t.Components[i], componentName(t, i)
+			}
+
+			component, err := formatValue(elem, elemName, v.Index(i).Interface())
+			if err != nil {
+				return FormattedValue{}, err
+			}
+			formatted.Components[i] = component
+		}
+
+		return formatted, nil
+	}
+
+	switch val := value.(type) {
+	case common.Address:
+		formatted.Value = val.Hex()
+	case *common.Address:
+		formatted.Value = val.Hex()
+	case string:
+		switch {
+		case t.Kind == AddressKind && common.IsHexAddress(val):
+			formatted.Value = common.HexToAddress(val).Hex()
+		case t.Kind == StringKind && utf8.ValidString(val):
+			formatted.Value = strconv.Quote(val)
+		default:
+			formatted.Value = "0x" + hex.EncodeToString([]byte(val))
+		}
+	case []byte:
+		if t.Kind == FixedBytesKind && len(val) > t.Size {
+			val = val[:t.Size] // i.e. the padded word returned by Decode
+		}
+		formatted.Value = "0x" + hex.EncodeToString(val)
+	case bool:
+		formatted.Value = strconv.FormatBool(val)
+	case *big.Int:
+		formatted.Value = val.String()
+	case *Decimal:
+		formatted.Value = val.String()
+	default:
+		b, ok := byteArrayToSlice(value)
+		if !ok {
+			return FormattedValue{}, fmt.Errorf("%v: invalid value type for %v: %T", name, t, value)
+		}
+		formatted.Value = "0x" + hex.EncodeToString(b)
+	}
+
+	return formatted, nil
+}
+
+// parseNamedSignature parses a function signature whose parameters
+// may be named, i.e. `transfer(address to, uint256 amount)`, into the
+// function name and its parameters tuple. Calls ParseFunction
+// function.
+func parseNamedSignature(funcSignature string) (string, *Type, error) {
+	inputSig, _, _, err := splitSignature(strings.TrimSpace(funcSignature))
+	if err != nil {
+		return "", nil, err
+	}
+
+	method, err := ParseFunction(inputSig)
+	if err != nil {
+		return "", nil, err
+	}
+
+	t, err := ParseType(namedTupleType(method.Inputs))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return method.Name, t, nil
+}
+
+// namedTupleType returns the tuple type string of given arguments,
+// keeping their names, i.e. `(address to,(uint256 amount) permit)`.
+func namedTupleType(args []Argument) string {
+	params := make([]string, len(args))
+	for i, arg := range args {
+		params[i] = arg.Type
+		if len(arg.Components) > 0 {
+			unnamed := "(" + strings.Join(argumentTypes(arg.Components), ",") + ")"
+			params[i] = namedTupleType(arg.Components) + arg.Type[len(unnamed):]
+		}
+
+		if arg.Name != "" {
+			params[i] += " " + arg.Name
+		}
+	}
+
+	return "(" + strings.Join(params, ",") + ")"
+}
+
+// componentName returns the name of the i-th tuple component, or its
+// position when the tuple has no names.
+func componentName(t *Type, i int) string {
+	if t.Names != nil && t.Names[i] != "" {
+		return t.Names[i]
+	}
+
+	return "[" + strconv.Itoa(i) + "]"
+}
+
+// integerHex returns the hex representation of an integer value, or
+// an empty string for other types.
+func integerHex(v FormattedValue) string {
+	if !strings.HasPrefix(v.Type, "int") && !strings.HasPrefix(v.Type, "uint") {
+		return ""
+	}
+
+	integer, ok := new(big.Int).SetString(v.Value, 10)
+	if !ok {
+		return ""
+	}
+
+	if integer.Sign() == -1 {
+		return "-0x" + new(big.Int).Neg(integer).Text(16)
+	}
+
+	return "0x" + integer.Text(16)
+}
@@ -0,0 +1,116 @@
+package abi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleFormatCall() {
+	funcSignature := "submit(address to,(string memo,bytes data,uint8[] flags) order,bytes4,int256 delta)"
+	data, err := abi.EncodeWithSignature(
+		"submit(address,(string,bytes,uint8[]),bytes4,int256)",
+		common.HexToAddress("0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789"),
+		[]any{"gm ☀", []byte{0xde, 0xad}, []any{big.NewInt(1), big.NewInt(255)}},
+		[]byte{0xa9, 0x05, 0x9c, 0xbb},
+		big.NewInt(-1000),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	call, err := abi.FormatCall(funcSignature, data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Print(call)
+
+	// Output:
+	// submit(address,(string,bytes,uint8[]),bytes4,int256) 0x86a6654c
+	//   to (address): 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789
+	//   order ((string,bytes,uint8[])):
+	//     memo (string): "gm ☀"
+	//     data (bytes): 0xdead
+	//     flags (uint8[]):
+	//       [0] (uint8): 1 (0x1)
+	//       [1] (uint8): 255 (0xff)
+	//   [2] (bytes4): 0xa9059cbb
+	//   delta (int256): -1000 (-0x3e8)
+}
+
+func ExampleFormatCall_spacedSignature() {
+	funcSignature := "transfer(address to, uint256 amount)"
+	data := common.Hex2Bytes("a9059cbb0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d278900000000000000000000000000000000000000000000000000000000000003e8")
+
+	call, err := abi.FormatCall(funcSignature, data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Print(call)
+
+	// Output:
+	// transfer(address,uint256) 0xa9059cbb
+	//   to (address): 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789
+	//   amount (uint256): 1000 (0x3e8)
+}
+
+func ExampleNewFormattedCall() {
+	funcSignature := "transfer(address to,uint256 amount)"
+	data := common.Hex2Bytes("a9059cbb0000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d278900000000000000000000000000000000000000000000000000000000000003e8")
+
+	values, err := abi.DecodeWithSignature("transfer(address,uint256)", data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	call, err := abi.NewFormattedCall(funcSignature, values)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	encoded, err := json.Marshal(call)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(encoded))
+
+	// Output: {"function":"transfer","signature":"transfer(address,uint256)","selector":"0xa9059cbb","params":[{"name":"to","type":"address","value":"0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"},{"name":"amount","type":"uint256","value":"1000"}]}
+}
+
+func ExampleNewFormattedCall_fixedBytes() {
+	funcSignature := "approve(bytes4 selector,bytes32 salt)"
+	data, err := abi.EncodeWithSignature("approve(bytes4,bytes32)", [4]byte{0xa9, 0x05, 0x9c, 0xbb}, [32]byte{31: 1})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	values, err := abi.DecodeWithSignature("approve(bytes4,bytes32)", data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	call, err := abi.NewFormattedCall(funcSignature, values)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	formatted, err := abi.FormatCall(funcSignature, data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Print(call)
+	fmt.Println(call.String() == formatted.String())
+
+	// Output:
+	// approve(bytes4,bytes32) 0xd4871192
+	//   selector (bytes4): 0xa9059cbb
+	//   salt (bytes32): 0x0000000000000000000000000000000000000000000000000000000000000001
+	// true
+}
@@ -0,0 +1,350 @@
+package abi
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FuzzEncode checks that Encode matches the encoding of go-ethereum
+// accounts/abi for random type trees and values, and rejects integers
+// out of range.
+func FuzzEncode(f *testing.F) {
+	for seed := int64(0); seed < 16; seed++ {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		g := newFuzzGenerator(seed)
+		types := g.types()
+		values, gethValues := g.values(types)
+
+		encoded, err := encodeTuple(types, values)
+		if err != nil {
+			t.Fatalf("Encode(%v) error: %v", types, err)
+		}
+
+		expected, err := gethArguments(types).Pack(gethValues...)
+		if err != nil {
+			t.Fatalf("geth Pack(%v) error: %v", types, err)
+		}
+
+		if !bytes.Equal(encoded, expected) {
+			t.Fatalf("Encode(%v) mismatch:\n got 0x%x\nwant 0x%x", types, encoded, expected)
+		}
+
+		integer := fuzzIntegerType(g.rand)
+		if _, err := encodeTuple([]*Type{integer}, []any{g.outOfRange(integer)}); err == nil {
+			t.Fatalf("Encode(%v) accepted an out of range value", integer)
+		}
+	})
+}
+
+// FuzzDecode checks that decoding arbitrary bytes to random types
+// never panics, and that strictly decoded values re-encode to the
+// same bytes.
+func FuzzDecode(f *testing.F) {
+	f.Add(int64(0), []byte{})
+	f.Add(int64(1), bytes.Repeat([]byte{0xff}, 96))
+	f.Add(int64(2), common.FromHex("0x0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000568656c6c6f000000000000000000000000000000000000000000000000000000"))
+
+	f.Fuzz(func(t *testing.T, seed int64, data []byte) {
+		types := newFuzzGenerator(seed).types()
+
+		decoder{}.decodeTuple(types, data)
+		decoder{typed: true}.decodeTuple(types, data)
+		values, err := decoder{typed: true, strict: true}.decodeTuple(types, data)
+		if err != nil {
+			return
+		}
+
+		encoded, err := encodeTuple(types, values)
+		if err != nil {
+			t.Fatalf("Encode(%v) of strictly decoded values error: %v", types, err)
+		}
+
+		if !bytes.HasPrefix(data, encoded) {
+			t.Fatalf("Encode(%v) of strictly decoded values mismatch:\n got 0x%x\nwant 0x%x", types, encoded, data)
+		}
+	})
+}
+
+// FuzzRoundTrip checks that random values of random type trees
+// decode to the same encoding, strictly, and that go-ethereum
+// accounts/abi unpacks the encoding.
+func FuzzRoundTrip(f *testing.F) {
+	for seed := int64(0); seed < 16; seed++ {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		g := newFuzzGenerator(seed)
+		types := g.types()
+		values, _ := g.values(types)
+
+		encoded, err := encodeTuple(types, values)
+		if err != nil {
+			t.Fatalf("Encode(%v) error: %v", types, err)
+		}
+
+		decoded, err := decoder{typed: true, strict: true}.decodeTuple(types, encoded)
+		if err != nil {
+			t.Fatalf("DecodeStrict(%v) error: %v", types, err)
+		}
+
+		reencoded, err := encodeTuple(types, decoded)
+		if err != nil {
+			t.Fatalf("Encode(%v) of decoded values error: %v", types, err)
+		}
+
+		if !bytes.Equal(encoded, reencoded) {
+			t.Fatalf("round trip of %v mismatch:\n got 0x%x\nwant 0x%x", types, reencoded, encoded)
+		}
+
+		if _, err := gethArguments(types).Unpack(encoded); err != nil {
+			t.Fatalf("geth Unpack(%v) error: %v", types, err)
+		}
+	})
+}
+
+// fuzzElementaryTypes are the elementary types of validCoreTypes,
+// sorted for reproducible generation.
+var fuzzElementaryTypes = func() []string {
+	var typeStrs []string
+	for typeStr := range validCoreTypes {
+		typeStrs = append(typeStrs, typeStr)
+	}
+	sort.Strings(typeStrs)
+
+	return typeStrs
+}()
+
+// fuzzGenerator generates random type trees and values.
+type fuzzGenerator struct {
+	rand *rand.Rand
+}
+
+// newFuzzGenerator creates a generator seeded with given seed.
+func newFuzzGenerator(seed int64) *fuzzGenerator {
+	return &fuzzGenerator{rand: rand.New(rand.NewSource(seed))}
+}
+
+// types returns 1 to 4 random types.
+func (g *fuzzGenerator) types() []*Type {
+	types := make([]*Type, 1+g.rand.Intn(4))
+	for i := range types {
+		types[i] = g.typ(0)
+	}
+
+	return types
+}
+
+// typ returns a random type, with nested tuples and arrays up to a
+// depth of 3.
+func (g *fuzzGenerator) typ(depth int) *Type {
+	choice := 0
+	if depth < 3 {
+		choice = g.rand.Intn(5)
+	}
+
+	var typeStr string
+	switch choice {
+	case 1:
+		typeStr = g.typ(depth+1).String() + "[]"
+	case 2:
+		typeStr = g.typ(depth+1).String() + "[" + strconv.Itoa(1+g.rand.Intn(3)) + "]"
+	case 3:
+		components := make([]string, 1+g.rand.Intn(3))
+		for i := range components {
+			components[i] = g.typ(depth + 1).String()
+		}
+		typeStr = "(" + strings.Join(components, ",") + ")"
+	default:
+		typeStr = fuzzElementaryTypes[g.rand.Intn(len(fuzzElementaryTypes))]
+	}
+
+	t, err := ParseType(typeStr)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// values returns random values of given types, for Encode and for
+// go-ethereum Pack.
+func (g *fuzzGenerator) values(types []*Type) ([]any, []any) {
+	values := make([]any, len(types))
+	gethValues := make([]any, len(types))
+	for i, t := range types {
+		gethValue := reflect.New(gethType(t).GetType()).Elem()
+		values[i] = g.value(t, gethValue)
+		gethValues[i] = gethValue.Interface()
+	}
+
+	return values, gethValues
+}
+
+// value returns a random value of given type, and sets the
+// go-ethereum value to the same value.
+func (g *fuzzGenerator) value(t *Type, gethValue reflect.Value) any {
+	switch t.Kind {
+	case SliceKind, ArrayKind:
+		n := t.Length
+		if t.Kind == SliceKind {
+			n = g.rand.Intn(4)
+			gethValue.Set(reflect.MakeSlice(gethValue.Type(), n, n))
+		}
+
+		values := make([]any, n)
+		for i := range values {
+			values[i] = g.value(t.Elem, gethValue.Index(i))
+		}
+		return values
+	case TupleKind:
+		values := make([]any, len(t.Components))
+		for i, component := range t.Components {
+			values[i] = g.value(component, gethValue.Field(i))
+		}
+		return values
+	case IntKind, UintKind:
+		value := g.integer(t)
+		if gethValue.Kind() == reflect.Ptr {
+			gethValue.Set(reflect.ValueOf(value))
+		} else if t.Kind == IntKind {
+			gethValue.SetInt(value.Int64())
+		} else {
+			gethValue.SetUint(value.Uint64())
+		}
+		return value
+	case AddressKind:
+		var value common.Address
+		g.rand.Read(value[:])
+		gethValue.Set(reflect.ValueOf(value))
+		return value
+	case BoolKind:
+		value := g.rand.Intn(2) == 1
+		gethValue.SetBool(value)
+		return value
+	case FixedBytesKind:
+		value := make([]byte, t.Size)
+		g.rand.Read(value)
+		reflect.Copy(gethValue, reflect.ValueOf(value))
+		return value
+	case BytesKind:
+		value := make([]byte, g.rand.Intn(70))
+		g.rand.Read(value)
+		gethValue.SetBytes(value)
+		return value
+	case StringKind:
+		value := make([]byte, g.rand.Intn(70))
+		g.rand.Read(value)
+		gethValue.SetString(string(value))
+		return string(value)
+	default:
+		panic("unsupported fuzz type " + t.String())
+	}
+}
+
+// integer returns a random integer within the range of given type,
+// often one of its bounds.
+func (g *fuzzGenerator) integer(t *Type) *big.Int {
+	min, max := integerBounds(t)
+	switch g.rand.Intn(4) {
+	case 0:
+		return min
+	case 1:
+		return max
+	default:
+		span := new(big.Int).Sub(max, min)
+		value := new(big.Int).Rand(g.rand, span.Add(span, one))
+		return value.Add(value, min)
+	}
+}
+
+// outOfRange returns an integer just out of the range of given type.
+func (g *fuzzGenerator) outOfRange(t *Type) *big.Int {
+	min, max := integerBounds(t)
+	if g.rand.Intn(2) == 0 {
+		return min.Sub(min, one)
+	}
+
+	return max.Add(max, one)
+}
+
+// fuzzIntegerType returns a random integer type.
+func fuzzIntegerType(r *rand.Rand) *Type {
+	kind := UintKind
+	if r.Intn(2) == 0 {
+		kind = IntKind
+	}
+
+	return &Type{Kind: kind, Size: 8 * (1 + r.Intn(32))}
+}
+
+// integerBounds returns the bounds of given integer type, computed
+// from its bit size.
+func integerBounds(t *Type) (*big.Int, *big.Int) {
+	if t.Kind == UintKind {
+		max := new(big.Int).Lsh(one, uint(t.Size))
+		return new(big.Int), max.Sub(max, one)
+	}
+
+	max := new(big.Int).Lsh(one, uint(t.Size-1))
+	min := new(big.Int).Neg(max)
+	return min, max.Sub(max, one)
+}
+
+// gethArguments returns the go-ethereum arguments of given types.
+func gethArguments(types []*Type) gethabi.Arguments {
+	arguments := make(gethabi.Arguments, len(types))
+	for i, typ := range types {
+		arguments[i] = gethabi.Argument{Type: gethType(typ)}
+	}
+
+	return arguments
+}
+
+// gethType returns the go-ethereum type of given type.
+func gethType(t *Type) gethabi.Type {
+	marshaling := gethMarshaling(t, "")
+	typ, err := gethabi.NewType(marshaling.Type, "", marshaling.Components)
+	if err != nil {
+		panic(err)
+	}
+
+	return typ
+}
+
+// gethMarshaling returns the go-ethereum JSON argument of given type.
+// Tuple components are named after their position.
+func gethMarshaling(t *Type, name string) gethabi.ArgumentMarshaling {
+	suffix := ""
+	for t.Kind == ArrayKind || t.Kind == SliceKind {
+		if t.Kind == ArrayKind {
+			suffix = "[" + strconv.Itoa(t.Length) + "]" + suffix
+		} else {
+			suffix = "[]" + suffix
+		}
+		t = t.Elem
+	}
+
+	if t.Kind != TupleKind {
+		return gethabi.ArgumentMarshaling{Name: name, Type: t.String() + suffix}
+	}
+
+	components := make([]gethabi.ArgumentMarshaling, len(t.Components))
+	for i, component := range t.Components {
+		components[i] = gethMarshaling(component, "f"+strconv.Itoa(i))
+	}
+
+	return gethabi.ArgumentMarshaling{Name: name, Type: "tuple" + suffix, Components: components}
+}
@@ -0,0 +1,332 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxGuesses is the maximum number of type guesses kept at each level.
+const maxGuesses = 8
+
+// maxGuessDepth is the maximum nesting depth of guessed dynamic types.
+const maxGuessDepth = 8
+
+// GuessTypes infers plausible parameter types of ABI encoded data
+// without its ABI, i.e. of calldata with an unknown selector without
+// its first 4 bytes. It relies on the head/tail structure of the
+// encoding:
+//   - offsets pointing inside the data are dynamic values;
+//   - a length word followed by zero padded data is bytes or, when
+//     printable UTF-8, a string;
+//   - a length word followed by as many words or offsets is an array;
+//   - words with 12 leading zero bytes and a large value are addresses;
+//   - 0 and 1 words are bools.
+//
+// Returns comma separated type strings, best guess first, i.e.
+// `address,uint256,bytes`, which can be given to Decode through
+// SplitParams. Every guess is verified to re-encode to the exact same
+// data with Encode.
+func GuessTypes(data []byte) ([]string, error) {
+	if len(data)%32 != 0 {
+		return nil, fmt.Errorf("invalid data length %d: not a multiple of 32", len(data))
+	}
+
+	if len(data) == 0 {
+		return []string{""}, nil
+	}
+
+	var guesses []string
+	for _, typeStrs := range guessTuple(data, 0) {
+		values, err := DecodeTyped(typeStrs, data)
+		if err != nil {
+			continue
+		}
+
+		encoded, err := Encode(typeStrs, values...)
+		if err != nil || !bytes.Equal(encoded, data) {
+			continue
+		}
+
+		guesses = append(guesses, strings.Join(typeStrs, ","))
+	}
+
+	if len(guesses) == 0 {
+		return nil, fmt.Errorf("no parameter types match data")
+	}
+
+	return guesses, nil
+}
+
+// guessTuple guesses the component types of a tuple encoding. The
+// head ends at the first offset pointing inside the data, and each
+// tail ends at the next offset or at the end of the data.
+func guessTuple(data []byte, depth int) [][]string {
+	headEnd := len(data)
+	var alternatives [][]string
+	var offsets, offsetIndexes []int
+	for pos := 0; pos < headEnd; pos += 32 {
+		word := data[pos : pos+32]
+
+		minOffset := pos + 32
+		if len(offsets) > 0 {
+			minOffset = offsets[len(offsets)-1] + 32
+		}
+
+		if offset, ok := readWordInt(word); ok && depth < maxGuessDepth && offset%32 == 0 && offset >= minOffset && offset < len(data) {
+			if len(offsets) == 0 {
+				headEnd = offset
+			}
+			offsets = append(offsets, offset)
+			offsetIndexes = append(offsetIndexes, len(alternatives))
+			alternatives = append(alternatives, nil)
+			continue
+		}
+
+		alternatives = append(alternatives, guessWord(word))
+	}
+
+	for i, offset := range offsets {
+		end := len(data)
+		if i+1 < len(offsets) {
+			end = offsets[i+1]
+		}
+
+		guesses := guessDynamic(data[offset:end], depth+1)
+		if len(guesses) == 0 {
+			return nil
+		}
+		alternatives[offsetIndexes[i]] = guesses
+	}
+
+	return combineGuesses(alternatives, maxGuesses)
+}
+
+// guessDynamic guesses the type of a dynamic value encoding, i.e.
+// bytes, string, an array or a tuple with dynamic components.
+func guessDynamic(data []byte, depth int) []string {
+	length, ok := readWordInt(data)
+
+	var guesses []string
+	if padded := 32 + (length+31)/32*32; ok && padded == len(data) && isZero(data[32+length:]) {
+		if content := data[32 : 32+length]; length > 0 && isPrintable(content) {
+			guesses = append(guesses, "string", "bytes")
+		} else if length > 0 {
+			guesses = append(guesses, "bytes")
+		} else {
+			guesses = append(guesses, "bytes", "string", "uint256[]")
+		}
+	}
+
+	if ok && length > 0 && length <= (len(data)-32)/32 {
+		elems := data[32:]
+		for _, elemType := range guessDynamicElems(elems, length, depth) {
+			guesses = append(guesses, elemType+"[]")
+		}
+
+		if len(elems)%(32*length) == 0 {
+			guesses = append(guesses, guessStaticElem(elems, length, len(elems)/(32*length))+"[]")
+		}
+	}
+
+	for _, typeStrs := range guessTuple(data, depth) {
+		tupleType := "(" + strings.Join(typeStrs, ",") + ")"
+		if t, err := ParseType(tupleType); err == nil && t.IsDynamic() {
+			guesses = append(guesses, tupleType)
+		}
+	}
+
+	if len(guesses) > maxGuesses {
+		guesses = guesses[:maxGuesses]
+	}
+
+	return guesses
+}
+
+// guessDynamicElems guesses the common type of n dynamic array
+// elements, which encoding starts with n offsets.
+func guessDynamicElems(elems []byte, n int, depth int) []string {
+	if depth >= maxGuessDepth {
+		return nil
+	}
+
+	offsets := make([]int, n)
+	for i := range offsets {
+		offset, ok := readWordInt(elems[i*32:])
+		minOffset := n * 32
+		if i > 0 {
+			minOffset = offsets[i-1] + 32
+		}
+		if !ok || offset%32 != 0 || offset < minOffset || offset >= len(elems) {
+			return nil
+		}
+		offsets[i] = offset
+	}
+
+	lists := make([][]string, n)
+	for i, offset := range offsets {
+		end := len(elems)
+		if i+1 < n {
+			end = offsets[i+1]
+		}
+
+		lists[i] = guessDynamic(elems[offset:end], depth+1)
+	}
+
+	return commonGuesses(lists)
+}
+
+// guessStaticElem guesses the common type of n static array elements
+// of given word size, i.e. a tuple of elementary types when more than
+// one word.
+func guessStaticElem(elems []byte, n int, words int) string {
+	components := make([]string, words)
+	for j := range components {
+		lists := make([][]string, n)
+		for i := range lists {
+			pos := (i*words + j) * 32
+			lists[i] = guessWord(elems[pos : pos+32])
+		}
+
+		components[j] = commonGuesses(lists)[0]
+	}
+
+	if words == 1 {
+		return components[0]
+	}
+
+	return "(" + strings.Join(components, ",") + ")"
+}
+
+// guessWord guesses the elementary type of a 32-byte head word.
+// Any word re-encodes as uint256, int256 and bytes32.
+func guessWord(word []byte) []string {
+	switch {
+	case isZero(word):
+		return []string{"bool", "address", "uint256", "int256", "bytes32"}
+	case isZero(word[:31]) && word[31] == 1:
+		return []string{"bool", "uint256", "int256", "bytes32"}
+	case isZero(word[:12]) && !isZero(word[12:16]):
+		return []string{"address", "uint256", "int256", "bytes32"}
+	case isZero(word[:16]):
+		return []string{"uint256", "int256", "bytes32"}
+	case bytes.Count(word[:16], []byte{0xff}) == 16:
+		return []string{"int256", "uint256", "bytes32"}
+	default:
+		return []string{"bytes32", "uint256", "int256"}
+	}
+}
+
+// combineGuesses combines the guesses of each tuple component into at
+// most limit tuple guesses, ordered by the sum of the component guess
+// ranks.
+func combineGuesses(alternatives [][]string, limit int) [][]string {
+	maxRank := 0
+	for _, guesses := range alternatives {
+		maxRank += len(guesses) - 1
+	}
+
+	var combos [][]string
+	var walk func(i int, budget int, combo []string)
+	walk = func(i int, budget int, combo []string) {
+		if len(combos) >= limit {
+			return
+		}
+
+		if i == len(alternatives) {
+			if budget == 0 {
+				combos = append(combos, append([]string{}, combo...))
+			}
+			return
+		}
+
+		for j := 0; j < len(alternatives[i]) && j <= budget; j++ {
+			walk(i+1, budget-j, append(combo, alternatives[i][j]))
+		}
+	}
+
+	for rank := 0; rank <= maxRank && len(combos) < limit; rank++ {
+		walk(0, rank, make([]string, 0, len(alternatives)))
+	}
+
+	return combos
+}
+
+// commonGuesses returns the guesses found in all lists, ordered by the
+// sum of their ranks in each list.
+func commonGuesses(lists [][]string) []string {
+	if len(lists) == 0 {
+		return nil
+	}
+
+	ranks := make(map[string]int)
+	var common []string
+	for _, guess := range lists[0] {
+		rank, found := 0, true
+		for _, list := range lists {
+			index := indexOf(list, guess)
+			if index == -1 {
+				found = false
+				break
+			}
+			rank += index
+		}
+
+		if found {
+			ranks[guess] = rank
+			common = append(common, guess)
+		}
+	}
+
+	sort.SliceStable(common, func(i, j int) bool {
+		return ranks[common[i]] < ranks[common[j]]
+	})
+
+	return common
+}
+
+// indexOf returns the index of s in list, or -1 if not found.
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// readWordInt reads the first 32-byte word of data as a small
+// non-negative integer, i.e. an offset or a length.
+func readWordInt(data []byte) (int, bool) {
+	if len(data) < 32 || !isZero(data[:28]) {
+		return 0, false
+	}
+
+	value := binary.BigEndian.Uint32(data[28:32])
+	if value > 1<<30 {
+		return 0, false
+	}
+
+	return int(value), true
+}
+
+// isPrintable checks whether given bytes are UTF-8 text made of
+// printable characters and spaces.
+func isPrintable(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleGuessTypes() {
+	calldata, err := abi.EncodeWithSignature(
+		"unknown(address,uint256,string,address[])",
+		common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"),
+		big.NewInt(1000),
+		"hello world",
+		[]any{
+			common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"),
+			common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		},
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	guesses, err := abi.GuessTypes(calldata[4:])
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(guesses[0])
+
+	decoded, err := abi.Decode(abi.SplitParams(guesses[0]), calldata[4:])
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(decoded)
+
+	// Output:
+	// address,uint256,string,address[]
+	// [0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 1000 hello world [0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2]]
+}
+
+func ExampleGuessTypes_second() {
+	data, err := abi.Encode(
+		[]string{"(address,uint256)[]", "bytes"},
+		[]any{
+			[]any{common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"), big.NewInt(5)},
+			[]any{common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), big.NewInt(6)},
+		},
+		[]byte{0xde, 0xad, 0xbe, 0xef},
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	guesses, err := abi.GuessTypes(data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(guesses[0])
+
+	// Output: (address,uint256)[],bytes
+}
@@ -0,0 +1,510 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LoadHumanReadable loads a contract from its human-readable ABI, one
+// entry per line, i.e.:
+//
+//	struct Permit { address owner; uint256 amount; }
+//	function transfer(address to, uint256 amount) external returns (bool)
+//	function permit(Permit permit, bytes signature)
+//	function getReserves() view returns (uint112 reserve0, uint112 reserve1, uint32)
+//	event Transfer(address indexed from, address indexed to, uint256 value)
+//	error InsufficientBalance(uint256 available, uint256 required)
+//	constructor(string name, string symbol)
+//	receive() external payable
+//
+// Tuples are written either as `tuple(address owner, uint256 amount)`,
+// `(address,uint256)` or as a reference to a struct declared on any line.
+// Recursive structs are rejected.
+// Aliases such as `uint` are resolved to their canonical types.
+func LoadHumanReadable(lines ...string) (*Contract, error) {
+	structs := &hrStructs{
+		lines:     make(map[string]string),
+		parsed:    make(map[string][]jsonArgument),
+		resolving: make(map[string]bool),
+	}
+	var structNames, entryLines []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "struct ") {
+			name, err := parseStructName(line)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := structs.lines[name]; ok {
+				return nil, fmt.Errorf("invalid human-readable ABI %q: duplicate struct %v", line, name)
+			}
+			structs.lines[name] = line
+			structNames = append(structNames, name)
+			continue
+		}
+
+		entryLines = append(entryLines, line)
+	}
+
+	for _, name := range structNames {
+		if _, err := structs.components(name); err != nil {
+			return nil, err
+		}
+	}
+
+	contract := &Contract{
+		Functions: make(map[string]*Method),
+		Events:    make(map[string]*Event),
+		Errors:    make(map[string]*Error),
+	}
+	for _, line := range entryLines {
+		entry, err := parseEntryLine(line, structs)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := contract.addEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return contract, nil
+}
+
+// ParseFunction parses a human-readable function definition, i.e.
+// `function balanceOf(address owner) view returns (uint256)`. The
+// `function` keyword may be omitted.
+func ParseFunction(line string) (*Method, error) {
+	if !strings.HasPrefix(strings.TrimSpace(line), "function ") {
+		line = "function " + line
+	}
+
+	contract, err := LoadHumanReadable(line)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, method := range contract.Functions {
+		return method, nil
+	}
+
+	return nil, fmt.Errorf("no function found in %q", line)
+}
+
+// ParseEvent parses a human-readable event definition, i.e.
+// `event Transfer(address indexed from, address indexed to, uint256 value)`.
+// The `event` keyword may be omitted.
+func ParseEvent(line string) (*Event, error) {
+	if !strings.HasPrefix(strings.TrimSpace(line), "event ") {
+		line = "event " + line
+	}
+
+	contract, err := LoadHumanReadable(line)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range contract.Events {
+		return event, nil
+	}
+
+	return nil, fmt.Errorf("no event found in %q", line)
+}
+
+// ParseCustomError parses a human-readable custom error definition, i.e.
+// `error InsufficientBalance(uint256 available, uint256 required)`.
+// The `error` keyword may be omitted.
+func ParseCustomError(line string) (*Error, error) {
+	if !strings.HasPrefix(strings.TrimSpace(line), "error ") {
+		line = "error " + line
+	}
+
+	contract, err := LoadHumanReadable(line)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, abiError := range contract.Errors {
+		return abiError, nil
+	}
+
+	return nil, fmt.Errorf("no error found in %q", line)
+}
+
+// stateMutabilities are the state mutability keywords.
+var stateMutabilities = map[string]bool{
+	"pure":       true,
+	"view":       true,
+	"nonpayable": true,
+	"payable":    true,
+}
+
+// ignoredModifiers are keywords without effect on the ABI.
+var ignoredModifiers = map[string]bool{
+	"external": true,
+	"public":   true,
+	"virtual":  true,
+	"override": true,
+}
+
+// dataLocations are the data location keywords allowed after a
+// parameter type.
+var dataLocations = map[string]bool{
+	"calldata": true,
+	"memory":   true,
+	"storage":  true,
+}
+
+// hrParser is a parser for a human-readable ABI line.
+type hrParser struct {
+	line    string
+	tokens  []string
+	pos     int
+	structs *hrStructs
+}
+
+// hrStructs are the structs declared in a human-readable ABI. Structs
+// are parsed when first referenced, so that they can reference structs
+// declared on later lines.
+type hrStructs struct {
+	lines     map[string]string         // declaration lines by struct name
+	parsed    map[string][]jsonArgument // components by struct name
+	resolving map[string]bool           // structs being parsed, to detect cycles
+}
+
+// declared checks whether a struct of given name is declared.
+func (s *hrStructs) declared(name string) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.lines[name]
+	return ok
+}
+
+// components returns the components of the declared struct of given
+// name, parsing its declaration if needed.
+func (s *hrStructs) components(name string) ([]jsonArgument, error) {
+	if components, ok := s.parsed[name]; ok {
+		return components, nil
+	}
+
+	if s.resolving[name] {
+		return nil, fmt.Errorf("invalid human-readable ABI %q: recursive struct %v", s.lines[name], name)
+	}
+	s.resolving[name] = true
+
+	components, err := parseStructLine(s.lines[name], s)
+	if err != nil {
+		return nil, err
+	}
+
+	s.parsed[name] = components
+	return components, nil
+}
+
+// parseEntryLine parses a human-readable function, event, error,
+// constructor, fallback or receive line.
+func parseEntryLine(line string, structs *hrStructs) (jsonEntry, error) {
+	p, err := newHRParser(line, structs)
+	if err != nil {
+		return jsonEntry{}, err
+	}
+
+	var entry jsonEntry
+	switch keyword := p.peek(); keyword {
+	case "function", "event", "error":
+		p.next()
+		entry.Type = keyword
+		entry.Name = p.next()
+		if !isIdentifier(entry.Name) {
+			return jsonEntry{}, p.errorf("invalid %v name %q", keyword, entry.Name)
+		}
+	case "constructor", "fallback", "receive":
+		p.next()
+		entry.Type = keyword
+	default:
+		return jsonEntry{}, p.errorf("expected function, event, error, constructor, fallback or receive, got %q", keyword)
+	}
+
+	entry.Inputs, err = p.parseParams(entry.Type == "event")
+	if err != nil {
+		return jsonEntry{}, err
+	}
+
+	for p.pos < len(p.tokens) {
+		token := p.next()
+		switch {
+		case token == ";":
+			if p.pos != len(p.tokens) {
+				return jsonEntry{}, p.errorf("unexpected %q after ';'", p.peek())
+			}
+		case token == "returns" && entry.Type == "function":
+			entry.Outputs, err = p.parseParams(false)
+			if err != nil {
+				return jsonEntry{}, err
+			}
+		case token == "anonymous" && entry.Type == "event":
+			entry.Anonymous = true
+		case stateMutabilities[token] && entry.Type != "event" && entry.Type != "error":
+			entry.StateMutability = token
+		case token == "constant" && entry.Type == "function":
+			entry.StateMutability = "view"
+		case ignoredModifiers[token] && entry.Type != "event" && entry.Type != "error":
+		default:
+			return jsonEntry{}, p.errorf("unexpected %q", token)
+		}
+	}
+
+	if entry.StateMutability == "" && entry.Type != "event" && entry.Type != "error" {
+		entry.StateMutability = "nonpayable"
+		if entry.Type == "receive" {
+			entry.StateMutability = "payable"
+		}
+	}
+
+	return entry, nil
+}
+
+// parseStructName parses the name of a struct declaration.
+func parseStructName(line string) (string, error) {
+	p, err := newHRParser(line, nil)
+	if err != nil {
+		return "", err
+	}
+
+	p.next() // struct
+	name := p.next()
+	if !isIdentifier(name) {
+		return "", p.errorf("invalid struct name %q", name)
+	}
+
+	return name, nil
+}
+
+// parseStructLine parses the components of a struct declaration, i.e.
+// `struct Permit { address owner; uint256 amount; }`.
+func parseStructLine(line string, structs *hrStructs) ([]jsonArgument, error) {
+	p, err := newHRParser(line, structs)
+	if err != nil {
+		return nil, err
+	}
+
+	p.next() // struct
+	p.next() // name
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var components []jsonArgument
+	for p.peek() != "}" {
+		component, err := p.parseParam(false)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+
+		if err := p.expect(";"); err != nil {
+			return nil, err
+		}
+	}
+	p.next() // }
+
+	if p.peek() == ";" {
+		p.next()
+	}
+	if p.pos != len(p.tokens) {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+
+	return components, nil
+}
+
+// newHRParser tokenizes given line.
+func newHRParser(line string, structs *hrStructs) (*hrParser, error) {
+	p := &hrParser{line: line, structs: structs}
+
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.IndexByte("()[],;{}", c) != -1:
+			p.tokens = append(p.tokens, string(c))
+			i++
+		case isIdentifierChar(c):
+			start := i
+			for i < len(line) && isIdentifierChar(line[i]) {
+				i++
+			}
+			p.tokens = append(p.tokens, line[start:i])
+		default:
+			return nil, fmt.Errorf("invalid human-readable ABI %q: unexpected character %q", line, c)
+		}
+	}
+
+	return p, nil
+}
+
+// errorf returns an error for the parsed line.
+func (p *hrParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid human-readable ABI %q: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// peek returns the current token without consuming it.
+func (p *hrParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the current token.
+func (p *hrParser) next() string {
+	token := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return token
+}
+
+// expect consumes the current token, which must be given one.
+func (p *hrParser) expect(token string) error {
+	if got := p.next(); got != token {
+		if got == "" {
+			return p.errorf("expected %q, got end of line", token)
+		}
+		return p.errorf("expected %q, got %q", token, got)
+	}
+	return nil
+}
+
+// parseParams parses a parenthesized, comma separated parameter list.
+func (p *hrParser) parseParams(allowIndexed bool) ([]jsonArgument, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var params []jsonArgument
+	if p.peek() == ")" {
+		p.next()
+		return params, nil
+	}
+
+	for {
+		param, err := p.parseParam(allowIndexed)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+
+		switch token := p.next(); token {
+		case ",":
+		case ")":
+			return params, nil
+		default:
+			return nil, p.errorf("expected ',' or ')', got %q", token)
+		}
+	}
+}
+
+// parseParam parses a parameter: its type, optional `indexed` keyword
+// or data location, and optional name.
+func (p *hrParser) parseParam(allowIndexed bool) (jsonArgument, error) {
+	param, err := p.parseParamType()
+	if err != nil {
+		return jsonArgument{}, err
+	}
+
+	for {
+		token := p.peek()
+		switch {
+		case token == "indexed" && allowIndexed:
+			param.Indexed = true
+			p.next()
+		case dataLocations[token]:
+			p.next()
+		case isIdentifier(token) && param.Name == "":
+			param.Name = token
+			p.next()
+		default:
+			return param, nil
+		}
+	}
+}
+
+// parseParamType parses an elementary type, a tuple or a struct
+// reference, followed by any number of array suffixes.
+func (p *hrParser) parseParamType() (jsonArgument, error) {
+	var param jsonArgument
+
+	token := p.peek()
+	switch {
+	case token == "(" || token == "tuple":
+		if token == "tuple" {
+			p.next()
+		}
+
+		components, err := p.parseParams(false)
+		if err != nil {
+			return jsonArgument{}, err
+		}
+		param.Type = "tuple"
+		param.Components = components
+	case p.structs.declared(token):
+		p.next()
+		components, err := p.structs.components(token)
+		if err != nil {
+			return jsonArgument{}, err
+		}
+		param.Type = "tuple"
+		param.InternalType = "struct " + token
+		param.Components = components
+	case isIdentifier(token):
+		p.next()
+		t, err := ParseType(token)
+		if err != nil {
+			return jsonArgument{}, p.errorf("unknown type %q", token)
+		}
+		param.Type = t.String()
+	default:
+		return jsonArgument{}, p.errorf("expected type, got %q", token)
+	}
+
+	for p.peek() == "[" {
+		p.next()
+		suffix := "["
+		if p.peek() != "]" {
+			suffix += p.next()
+		}
+		if err := p.expect("]"); err != nil {
+			return jsonArgument{}, err
+		}
+		suffix += "]"
+
+		param.Type += suffix
+		if param.InternalType != "" {
+			param.InternalType += suffix
+		}
+	}
+
+	return param, nil
+}
+
+// isIdentifier checks whether given token is a Solidity identifier.
+func isIdentifier(token string) bool {
+	if token == "" || (token[0] >= '0' && token[0] <= '9') {
+		return false
+	}
+
+	for i := 0; i < len(token); i++ {
+		if !isIdentifierChar(token[i]) {
+			return false
+		}
+	}
+
+	return true
+}
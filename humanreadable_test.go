@@ -0,0 +1,110 @@
+package abi_test
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleLoadHumanReadable() {
+	contract, err := abi.LoadHumanReadable(
+		"struct Details { uint160 amount; uint48 nonce; }",
+		"struct Permit { address owner; Details details; }",
+		"function transfer(address to, uint amount) external returns (bool)",
+		"function permitBatch(Permit[] calldata permits, tuple(bytes32 r, bytes32 s, uint8 v) signature) external",
+		"function getReserves() view returns (uint112 reserve0, uint112 reserve1, uint32)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"error InsufficientBalance(uint256 available, uint256 required)",
+		"receive() external payable",
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	transfer, err := contract.Function("transfer")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	permitBatch, err := contract.Function("permitBatch")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	getReserves, err := contract.Function("getReserves")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	event, err := contract.Event("Transfer")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(transfer.Signature, common.Bytes2Hex(transfer.Selector), transfer.OutputTypes())
+	fmt.Println(permitBatch.Signature, permitBatch.Inputs[0].Name, permitBatch.Inputs[0].InternalType)
+	fmt.Println(getReserves.StateMutability, getReserves.OutputTypes(), getReserves.Outputs[0].Name)
+	fmt.Println(event.Signature, event.Inputs[0].Indexed, event.Inputs[2].Indexed)
+	fmt.Println(len(contract.Errors), contract.Receive.StateMutability)
+
+	// Output:
+	// transfer(address,uint256) a9059cbb [bool]
+	// permitBatch((address,(uint160,uint48))[],(bytes32,bytes32,uint8)) permits struct Permit[]
+	// view [uint112 uint112 uint32] reserve0
+	// Transfer(address,address,uint256) true false
+	// 1 payable
+}
+
+func ExampleLoadHumanReadable_structs() {
+	contract, err := abi.LoadHumanReadable(
+		"function fill(Order order, Empty empty)",
+		"struct Order { Permit permit; uint256 price; }",
+		"struct Permit { address owner; uint256 amount; }",
+		"struct Empty {}",
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for signature := range contract.Functions {
+		fmt.Println(signature)
+	}
+
+	_, err = abi.LoadHumanReadable(
+		"struct Node { Leaf leaf; }",
+		"struct Leaf { Node node; }",
+	)
+	fmt.Println(err)
+
+	// Output:
+	// fill(((address,uint256),uint256),())
+	// invalid human-readable ABI "struct Node { Leaf leaf; }": recursive struct Node
+}
+
+func ExampleParseFunction() {
+	method, err := abi.ParseFunction("balanceOf(address owner) view returns (uint)")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(method.Signature, common.Bytes2Hex(method.Selector), method.InputTypes(), method.OutputTypes())
+
+	_, err = abi.ParseFunction("function transfer(address to uint256 amount)")
+	fmt.Println(err)
+
+	// Output:
+	// balanceOf(address) 70a08231 [address] [uint256]
+	// invalid human-readable ABI "function transfer(address to uint256 amount)": expected ',' or ')', got "uint256"
+}
+
+func ExampleParseEvent() {
+	event, err := abi.ParseEvent("event Approval(address indexed owner, address indexed spender, uint256 value)")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(event.Signature, common.Bytes2Hex(event.Topic))
+
+	// Output: Approval(address,address,uint256) 8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925
+}
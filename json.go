@@ -0,0 +1,170 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DecodeToJSON decodes bytecode to provided types and returns the
+// values as a JSON array, with the canonical mapping:
+//   - intN and uintN: decimal strings, i.e. "-1000";
+//   - fixedMxN and ufixedMxN: decimal strings with N fractional digits, i.e. "1.500000000000000000";
+//   - address: checksummed hex strings;
+//   - bytes and bytesN: 0x-prefixed lowercase hex strings;
+//   - bool: JSON booleans;
+//   - string: JSON strings, or {"hex": "0x..."} when not valid UTF-8;
+//   - arrays: JSON arrays;
+//   - tuples: JSON objects when all components are named (i.e. `(address owner,uint256 amount)`),
+//     JSON arrays otherwise.
+//
+// The mapping is lossless: EncodeFromJSON re-encodes the result of a
+// canonical encoding byte for byte.
+func DecodeToJSON(typeStrs []string, data []byte) ([]byte, error) {
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decoder{typed: true}.decodeTuple(types, data)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonValues := make([]any, len(values))
+	for i, t := range types {
+		jsonValues[i], err = toJSONValue(t, values[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(jsonValues)
+}
+
+// EncodeFromJSON encodes a JSON array of values, following the
+// mapping of DecodeToJSON, based on provided types. Values are
+// parsed by ParseValuesJSON, which also accepts JSON numbers.
+func EncodeFromJSON(typeStrs []string, jsonData []byte) ([]byte, error) {
+	values, err := ParseValuesJSON(typeStrs, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	return Encode(typeStrs, values...)
+}
+
+// jsonField is a member of a jsonObject.
+type jsonField struct {
+	Name  string
+	Value any
+}
+
+// jsonObject is a JSON object keeping its members order.
+type jsonObject []jsonField
+
+// MarshalJSON implements json.Marshaler.
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(field.Name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// toJSONValue converts a typed decoded value of given type to its
+// canonical JSON value.
+func toJSONValue(t *Type, value any) (any, error) {
+	switch t.Kind {
+	case ArrayKind, SliceKind, TupleKind:
+		values, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid value type for %v: %T", t, value)
+		}
+
+		types, err := elementTypes(t, len(values))
+		if err != nil {
+			return nil, err
+		}
+
+		jsonValues := make([]any, len(values))
+		for i, val := range values {
+			jsonValues[i], err = toJSONValue(types[i], val)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if t.Kind == TupleKind && hasAllNames(t) {
+			object := make(jsonObject, len(jsonValues))
+			for i, val := range jsonValues {
+				object[i] = jsonField{Name: t.Names[i], Value: val}
+			}
+			return object, nil
+		}
+
+		return jsonValues, nil
+	}
+
+	switch val := value.(type) {
+	case *big.Int:
+		return val.String(), nil
+	case *Decimal:
+		return val.String(), nil
+	case common.Address:
+		return val.Hex(), nil
+	case bool:
+		return val, nil
+	case string:
+		if !utf8.ValidString(val) {
+			return jsonObject{{Name: "hex", Value: "0x" + hex.EncodeToString([]byte(val))}}, nil
+		}
+		return val, nil
+	case []byte:
+		return "0x" + hex.EncodeToString(val), nil
+	}
+
+	if b, ok := byteArrayToSlice(value); ok {
+		return "0x" + hex.EncodeToString(b), nil
+	}
+
+	return nil, fmt.Errorf("invalid value type for %v: %T", t, value)
+}
+
+// hasAllNames checks whether all components of given tuple are named.
+func hasAllNames(t *Type) bool {
+	if t.Names == nil {
+		return false
+	}
+
+	for _, name := range t.Names {
+		if name == "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,59 @@
+package abi_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleDecodeToJSON() {
+	typeStrs := []string{"(address owner,uint256 amount)[]", "int8", "bytes2", "string", "ufixed128x18"}
+	owner := common.HexToAddress("0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789")
+	encoded, err := abi.Encode(
+		typeStrs,
+		[]any{[]any{&owner, big.NewInt(1000)}},
+		big.NewInt(-1),
+		[]byte{0x12, 0x34},
+		"\xff",
+		abi.NewDecimal(big.NewInt(1500000000000000000), 18),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	jsonData, err := abi.DecodeToJSON(typeStrs, encoded)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	reencoded, err := abi.EncodeFromJSON(typeStrs, jsonData)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(jsonData))
+	fmt.Println(bytes.Equal(encoded, reencoded))
+
+	// Output:
+	// [[{"owner":"0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789","amount":"1000"}],"-1","0x1234",{"hex":"0xff"},"1.500000000000000000"]
+	// true
+}
+
+func ExampleEncodeFromJSON() {
+	encoded, err := abi.EncodeFromJSON([]string{"address", "uint256[]"}, []byte(`["0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789", [1, "0x2", "3e3"]]`))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	decoded, err := abi.Decode([]string{"address", "uint256[]"}, encoded)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(decoded)
+
+	// Output: [0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 [1 2 3000]]
+}
@@ -0,0 +1,435 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	addressType  = reflect.TypeOf(common.Address{})
+	byteType     = reflect.TypeOf(byte(0))
+	decimalType  = reflect.TypeOf(Decimal{})
+)
+
+// Marshal encodes given Go value based on provided type string, as
+// Encode([]string{typeStr}, value) would.
+//
+// Go structs map to tuples. Struct fields are matched to named tuple
+// components (i.e. `(address owner,uint256 amount)`) by their `abi`
+// tag, i.e. `abi:"owner"`, or by their name, ignoring case. For tuples
+// without component names, fields are taken in declaration order.
+// Partially named tuples are rejected. Fields tagged with `abi:"-"` and unexported fields are skipped.
+//
+// Go slices and arrays map to ABI arrays, common.Address to address,
+// *big.Int and Go integers to intN and uintN, [N]byte to bytesN, []byte
+// to bytes, Decimal and *big.Float to fixedMxN and ufixedMxN, string and
+// bool to their ABI counterparts.
+func Marshal(typeStr string, v any) ([]byte, error) {
+	t, err := ParseType(typeStr)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	value, err := toABIValue(t, reflect.ValueOf(v), "value")
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return encodeTuple([]*Type{t}, []any{value})
+}
+
+// Unmarshal decodes given bytecode based on provided type string and
+// stores the result in the value pointed to by out. The mapping of
+// ABI types to Go types follows the one of Marshal.
+func Unmarshal(typeStr string, data []byte, out any) error {
+	dst := reflect.ValueOf(out)
+	if dst.Kind() != reflect.Pointer || dst.IsNil() {
+		return fmt.Errorf("out must be a non-nil pointer, got %T", out)
+	}
+
+	t, err := ParseType(typeStr)
+	if err != nil {
+		return err
+	}
+
+	decoded, err := decoder{typed: true}.decodeTuple([]*Type{t}, data)
+	if err != nil {
+		return err
+	}
+
+	return assignABIValue(t, decoded[0], dst.Elem(), "value")
+}
+
+// MarshalValues encodes given Go values based on provided type
+// strings, as Encode(typeStrs, values...) would. Values are converted
+// as Marshal does.
+func MarshalValues(typeStrs []string, values ...any) ([]byte, error) {
+	if len(typeStrs) != len(values) {
+		return []byte{}, fmt.Errorf(
+			"typeStrs and values must have the same length. typeStrs: %v (length %v), values: %v (length %v)",
+			typeStrs,
+			len(typeStrs),
+			values,
+			len(values),
+		)
+	}
+
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	abiValues := make([]any, len(values))
+	for i, t := range types {
+		abiValues[i], err = toABIValue(t, reflect.ValueOf(values[i]), "value["+strconv.Itoa(i)+"]")
+		if err != nil {
+			return []byte{}, err
+		}
+	}
+
+	return encodeTuple(types, abiValues)
+}
+
+// UnmarshalValues decodes given bytecode based on provided type
+// strings and stores each value in the value pointed to by the
+// matching out, as Unmarshal does.
+func UnmarshalValues(typeStrs []string, data []byte, outs ...any) error {
+	if len(typeStrs) != len(outs) {
+		return fmt.Errorf("typeStrs and outs must have the same length. typeStrs: %v (length %v), outs length: %v", typeStrs, len(typeStrs), len(outs))
+	}
+
+	dsts := make([]reflect.Value, len(outs))
+	for i, out := range outs {
+		dsts[i] = reflect.ValueOf(out)
+		if dsts[i].Kind() != reflect.Pointer || dsts[i].IsNil() {
+			return fmt.Errorf("out must be a non-nil pointer, got %T", out)
+		}
+	}
+
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return err
+	}
+
+	decoded, err := decoder{typed: true}.decodeTuple(types, data)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range types {
+		if err := assignABIValue(t, decoded[i], dsts[i].Elem(), "value["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// toABIValue converts a Go value to the value accepted by the
+// encoders for given type. path qualifies the errors.
+func toABIValue(t *Type, v reflect.Value, path string) (any, error) {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil, fmt.Errorf("%v: cannot marshal nil into %v", path, t)
+		}
+		if v.Kind() == reflect.Pointer && (v.Elem().Type() == bigIntType || v.Elem().Type() == bigFloatType || v.Elem().Type() == addressType || v.Elem().Type() == decimalType) {
+			break
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil, fmt.Errorf("%v: cannot marshal nil into %v", path, t)
+	}
+
+	mismatch := fmt.Errorf("%v: cannot marshal %v into %v", path, v.Type(), t)
+
+	switch t.Kind {
+	case AddressKind:
+		switch val := v.Interface().(type) {
+		case common.Address:
+			return &val, nil
+		case *common.Address:
+			return val, nil
+		}
+	case BoolKind:
+		if v.Kind() == reflect.Bool {
+			return v.Bool(), nil
+		}
+	case StringKind:
+		if v.Kind() == reflect.String {
+			return v.String(), nil
+		}
+	case IntKind, UintKind:
+		switch val := v.Interface().(type) {
+		case *big.Int:
+			return val, nil
+		case big.Int:
+			return &val, nil
+		}
+
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return big.NewInt(v.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return new(big.Int).SetUint64(v.Uint()), nil
+		}
+	case FixedKind, UfixedKind:
+		switch val := v.Interface().(type) {
+		case *Decimal, *big.Float:
+			return val, nil
+		case Decimal:
+			return &val, nil
+		case big.Float:
+			return &val, nil
+		}
+	case FixedBytesKind, BytesKind:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), nil
+		}
+
+		if t.Kind == FixedBytesKind && v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.Len() != t.Size {
+				return nil, mismatch
+			}
+			val := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(val), v)
+			return val, nil
+		}
+	case ArrayKind, SliceKind:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			break
+		}
+
+		if t.Kind == ArrayKind && v.Len() != t.Length {
+			return nil, fmt.Errorf("%v: array size mismatch: expected %d, got %d", path, t.Length, v.Len())
+		}
+
+		values := make([]any, v.Len())
+		for i := range values {
+			val, err := toABIValue(t.Elem, v.Index(i), path+"["+strconv.Itoa(i)+"]")
+			if err != nil {
+				return nil, err
+			}
+			values[i] = val
+		}
+
+		return values, nil
+	case TupleKind:
+		switch v.Kind() {
+		case reflect.Struct:
+			fields, err := tupleFields(t, v.Type(), path)
+			if err != nil {
+				return nil, err
+			}
+
+			values := make([]any, len(t.Components))
+			for i, component := range t.Components {
+				val, err := toABIValue(component, v.FieldByIndex(fields[i].Index), path+"."+fields[i].Name)
+				if err != nil {
+					return nil, err
+				}
+				values[i] = val
+			}
+
+			return values, nil
+		case reflect.Slice, reflect.Array:
+			if v.Len() != len(t.Components) {
+				return nil, fmt.Errorf("%v: tuple size mismatch: expected %d, got %d", path, len(t.Components), v.Len())
+			}
+
+			values := make([]any, v.Len())
+			for i, component := range t.Components {
+				val, err := toABIValue(component, v.Index(i), path+"["+strconv.Itoa(i)+"]")
+				if err != nil {
+					return nil, err
+				}
+				values[i] = val
+			}
+
+			return values, nil
+		}
+	}
+
+	return nil, mismatch
+}
+
+// assignABIValue stores a decoded value of given type into dst.
+// path qualifies the errors.
+func assignABIValue(t *Type, decoded any, dst reflect.Value, path string) error {
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assignABIValue(t, decoded, dst.Elem(), path)
+	}
+
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		dst.Set(reflect.ValueOf(decoded))
+		return nil
+	}
+
+	mismatch := fmt.Errorf("%v: cannot unmarshal %v into %v", path, t, dst.Type())
+
+	switch t.Kind {
+	case AddressKind:
+		if dst.Type() == addressType {
+			dst.Set(reflect.ValueOf(decoded))
+			return nil
+		}
+	case BoolKind:
+		if dst.Kind() == reflect.Bool {
+			dst.SetBool(decoded.(bool))
+			return nil
+		}
+	case StringKind:
+		if dst.Kind() == reflect.String {
+			dst.SetString(decoded.(string))
+			return nil
+		}
+	case IntKind, UintKind:
+		val := decoded.(*big.Int)
+		if dst.Type() == bigIntType {
+			dst.Set(reflect.ValueOf(*val))
+			return nil
+		}
+
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !val.IsInt64() || dst.OverflowInt(val.Int64()) {
+				return fmt.Errorf("%v: value %v overflows %v", path, val, dst.Type())
+			}
+			dst.SetInt(val.Int64())
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if !val.IsUint64() || dst.OverflowUint(val.Uint64()) {
+				return fmt.Errorf("%v: value %v overflows %v", path, val, dst.Type())
+			}
+			dst.SetUint(val.Uint64())
+			return nil
+		}
+	case FixedKind, UfixedKind:
+		val := decoded.(*Decimal)
+		switch dst.Type() {
+		case decimalType:
+			dst.Set(reflect.ValueOf(*val))
+			return nil
+		case bigFloatType:
+			dst.Set(reflect.ValueOf(*val.Float()))
+			return nil
+		}
+	case FixedBytesKind, BytesKind:
+		val := reflect.ValueOf(decoded)
+		if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.Set(reflect.MakeSlice(dst.Type(), val.Len(), val.Len()))
+			reflect.Copy(dst, val)
+			return nil
+		}
+
+		if t.Kind == FixedBytesKind && dst.Kind() == reflect.Array && dst.Type().Elem().Kind() == reflect.Uint8 {
+			if dst.Len() != t.Size {
+				return mismatch
+			}
+			reflect.Copy(dst, val)
+			return nil
+		}
+	case ArrayKind, SliceKind:
+		values := decoded.([]any)
+		switch dst.Kind() {
+		case reflect.Slice:
+			dst.Set(reflect.MakeSlice(dst.Type(), len(values), len(values)))
+		case reflect.Array:
+			if dst.Len() != len(values) {
+				return fmt.Errorf("%v: array size mismatch: expected %d, got %d", path, dst.Len(), len(values))
+			}
+		default:
+			return mismatch
+		}
+
+		for i, val := range values {
+			if err := assignABIValue(t.Elem, val, dst.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case TupleKind:
+		values := decoded.([]any)
+		switch dst.Kind() {
+		case reflect.Struct:
+			fields, err := tupleFields(t, dst.Type(), path)
+			if err != nil {
+				return err
+			}
+
+			for i, component := range t.Components {
+				if err := assignABIValue(component, values[i], dst.FieldByIndex(fields[i].Index), path+"."+fields[i].Name); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		case reflect.Slice:
+			if dst.Type().Elem().Kind() != reflect.Interface {
+				return mismatch
+			}
+			dst.Set(reflect.ValueOf(values))
+			return nil
+		}
+	}
+
+	return mismatch
+}
+
+// tupleFields returns the struct fields matching each tuple component.
+func tupleFields(t *Type, structType reflect.Type, path string) ([]reflect.StructField, error) {
+	var fields []reflect.StructField
+	var names []string
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("abi"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, field)
+		names = append(names, name)
+	}
+
+	if t.Names == nil {
+		if len(fields) != len(t.Components) {
+			return nil, fmt.Errorf("%v: tuple size mismatch: %v has %d components, %v has %d fields", path, t, len(t.Components), structType, len(fields))
+		}
+		return fields, nil
+	}
+
+	matched := make([]reflect.StructField, len(t.Components))
+	for i, componentName := range t.Names {
+		if componentName == "" {
+			return nil, fmt.Errorf("%v: tuple %v has unnamed component %d, name all of its components or none", path, t, i)
+		}
+
+		found := false
+		for j, name := range names {
+			if name == componentName || (fields[j].Tag.Get("abi") == "" && strings.EqualFold(name, componentName)) {
+				matched[i] = fields[j]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%v: no field of %v matches tuple component %q", path, structType, componentName)
+		}
+	}
+
+	return matched, nil
+}
@@ -0,0 +1,97 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+type PermitDetails struct {
+	Amount *big.Int `abi:"amount"`
+	Nonce  uint64   `abi:"nonce"`
+}
+
+type Permit struct {
+	Owner   common.Address `abi:"owner"`
+	Details PermitDetails  `abi:"details"`
+	Salt    [4]byte        `abi:"salt"`
+	Note    string         `abi:"-"`
+}
+
+func ExampleMarshal() {
+	permits := []Permit{
+		{
+			Owner:   common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"),
+			Details: PermitDetails{Amount: big.NewInt(100), Nonce: 1},
+			Salt:    [4]byte{0xde, 0xad, 0xbe, 0xef},
+		},
+	}
+
+	encoded, err := abi.Marshal("(address owner,(uint160 amount,uint48 nonce) details,bytes4 salt)[]", permits)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(common.Bytes2Hex(encoded))
+
+	// Output: 000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000010000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d278900000000000000000000000000000000000000000000000000000000000000640000000000000000000000000000000000000000000000000000000000000001deadbeef00000000000000000000000000000000000000000000000000000000
+}
+
+func ExampleUnmarshal() {
+	encoded := common.Hex2Bytes("000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000010000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d278900000000000000000000000000000000000000000000000000000000000000640000000000000000000000000000000000000000000000000000000000000001deadbeef00000000000000000000000000000000000000000000000000000000")
+
+	var permits []Permit
+	err := abi.Unmarshal("(address owner,(uint160 amount,uint48 nonce) details,bytes4 salt)[]", encoded, &permits)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(permits[0].Owner, permits[0].Details.Amount, permits[0].Details.Nonce, common.Bytes2Hex(permits[0].Salt[:]))
+
+	// Output: 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 100 1 deadbeef
+}
+
+func ExampleMarshal_error() {
+	type transfer struct {
+		To     common.Address
+		Amount string
+	}
+
+	_, err := abi.Marshal("(address,uint256)[]", []transfer{{}})
+	fmt.Println(err)
+
+	// Output: value[0].Amount: cannot marshal string into uint256
+}
+
+func ExampleMarshal_partiallyNamed() {
+	type transfer struct {
+		To     common.Address
+		Amount *big.Int
+	}
+
+	_, err := abi.Marshal("(address to,uint256)", transfer{Amount: big.NewInt(1)})
+	fmt.Println(err)
+
+	// Output: value: tuple (address,uint256) has unnamed component 1, name all of its components or none
+}
+
+func ExampleMarshalValues() {
+	owner := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	encoded, err := abi.MarshalValues([]string{"address", "uint64", "bytes4"}, owner, uint64(7), [4]byte{0xde, 0xad, 0xbe, 0xef})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	var decodedOwner common.Address
+	var nonce uint64
+	var salt [4]byte
+	if err := abi.UnmarshalValues([]string{"address", "uint64", "bytes4"}, encoded, &decodedOwner, &nonce, &salt); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(decodedOwner, nonce, common.Bytes2Hex(salt[:]))
+
+	// Output: 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 7 deadbeef
+}
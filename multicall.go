@@ -0,0 +1,90 @@
+package abi
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Multicall3Address is the address of the Multicall3 contract, deployed
+// at the same address on most EVM chains.
+var Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// aggregate3Signature is the signature of Multicall3 aggregate3 function.
+const aggregate3Signature = "aggregate3((address,bool,bytes)[])"
+
+// MulticallCall is a call batched through Multicall3.
+type MulticallCall struct {
+	Target       common.Address
+	Signature    string   // function signature with outputs, i.e. `balanceOf(address)(uint256)`
+	Args         []any    // values accepted by Encode for the function inputs
+	AllowFailure bool     // whether the batch succeeds when this call reverts
+	Errors       []string // custom error signatures to decode reverts with, i.e. `InsufficientBalance(uint256,uint256)`
+}
+
+// MulticallResult is the result of a call batched through Multicall3.
+type MulticallResult struct {
+	Success    bool
+	ReturnData []byte
+	Values     []any // return data decoded to the call output types, if successful
+	Err        error // *RevertReason when the call reverted, the decoding error otherwise
+}
+
+// EncodeAggregate3 encodes a Multicall3 `aggregate3((address,bool,bytes)[])`
+// call batching given calls, to be sent to Multicall3Address.
+func EncodeAggregate3(calls []MulticallCall) ([]byte, error) {
+	batch := make([]any, len(calls))
+	for i, call := range calls {
+		callData, err := EncodeWithSignature(call.Signature, call.Args...)
+		if err != nil {
+			return []byte{}, fmt.Errorf("error encoding call %d (%v): %v", i, call.Signature, err)
+		}
+
+		target := call.Target
+		batch[i] = []any{&target, call.AllowFailure, callData}
+	}
+
+	return EncodeWithSignature(aggregate3Signature, batch)
+}
+
+// DecodeAggregate3 decodes the `(bool,bytes)[]` data returned by a
+// Multicall3 aggregate3 call and the return data of each call, based
+// on the output types of its signature. A failing call does not fail
+// the others: its result holds the decoded revert reason in Err.
+func DecodeAggregate3(calls []MulticallCall, data []byte) ([]MulticallResult, error) {
+	decoded, err := Decode([]string{"(bool,bytes)[]"}, data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding aggregate3 result: %v", err)
+	}
+
+	returned := decoded[0].([]any)
+	if len(returned) != len(calls) {
+		return nil, fmt.Errorf("aggregate3 result count mismatch: expected %d results, got %d", len(calls), len(returned))
+	}
+
+	results := make([]MulticallResult, len(calls))
+	for i, call := range calls {
+		result := returned[i].([]any)
+		results[i] = MulticallResult{
+			Success:    result[0].(bool),
+			ReturnData: result[1].([]byte),
+		}
+
+		if !results[i].Success {
+			reason, err := DecodeRevert(results[i].ReturnData, call.Errors...)
+			if err != nil {
+				results[i].Err = fmt.Errorf("call %d (%v) reverted: %v", i, call.Signature, err)
+			} else {
+				results[i].Err = reason
+			}
+			continue
+		}
+
+		results[i].Values, err = DecodeReturn(call.Signature, results[i].ReturnData)
+		if err != nil {
+			results[i].Err = fmt.Errorf("error decoding call %d (%v) result: %v", i, call.Signature, err)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,69 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleEncodeAggregate3() {
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	calls := []abi.MulticallCall{
+		{Target: token, Signature: "decimals()(uint8)"},
+		{Target: token, Signature: "balanceOf(address)(uint256)", Args: []any{common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")}, AllowFailure: true},
+	}
+
+	calldata, err := abi.EncodeAggregate3(calls)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	decoded, err := abi.DecodeWithSignature("aggregate3((address,bool,bytes)[])", calldata)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for _, call := range decoded[0].([]any) {
+		fmt.Println(call.([]any)[:2], common.Bytes2Hex(call.([]any)[2].([]byte)))
+	}
+
+	// Output:
+	// [0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 false] 313ce567
+	// [0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 true] 70a082310000000000000000000000005ff137d4b0fdcd49dca30c7cf57e578a026d2789
+}
+
+func ExampleDecodeAggregate3() {
+	calls := []abi.MulticallCall{
+		{Signature: "getReserves()(uint112,uint112,uint32)", AllowFailure: true},
+		{Signature: "withdraw(uint256)(bool)", AllowFailure: true, Errors: []string{"InsufficientBalance(uint256,uint256)"}},
+		{Signature: "decimals()(uint8)", AllowFailure: true},
+	}
+
+	reserves, _ := abi.EncodeReturn(calls[0].Signature, big.NewInt(1000), big.NewInt(2000), big.NewInt(1700000000))
+	insufficientBalance, _ := abi.EncodeWithSignature("InsufficientBalance(uint256,uint256)", big.NewInt(10), big.NewInt(20))
+	errorString, _ := abi.EncodeWithSignature("Error(string)", "not a token")
+	response, err := abi.Encode([]string{"(bool,bytes)[]"}, []any{
+		[]any{true, reserves},
+		[]any{false, insufficientBalance},
+		[]any{false, errorString},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	results, err := abi.DecodeAggregate3(calls, response)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for _, result := range results {
+		fmt.Println(result.Success, result.Values, result.Err)
+	}
+
+	// Output:
+	// true [1000 2000 1700000000] <nil>
+	// false [] execution reverted: InsufficientBalance(10, 20)
+	// false [] execution reverted: not a token
+}
@@ -0,0 +1,468 @@
+package abi
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Kind identifies the kind of an ABI type.
+type Kind int
+
+const (
+	IntKind        Kind = iota // intN
+	UintKind                   // uintN
+	AddressKind                // address
+	BoolKind                   // bool
+	FixedBytesKind             // bytesN
+	BytesKind                  // bytes
+	StringKind                 // string
+	FixedKind                  // fixedMxN
+	UfixedKind                 // ufixedMxN
+	ArrayKind                  // fixed length array, i.e. `uint256[3]`
+	SliceKind                  // dynamic length array, i.e. `uint256[]`
+	TupleKind                  // tuple, i.e. `(address,uint256)`
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case IntKind:
+		return "int"
+	case UintKind:
+		return "uint"
+	case AddressKind:
+		return "address"
+	case BoolKind:
+		return "bool"
+	case FixedBytesKind:
+		return "fixedbytes"
+	case BytesKind:
+		return "bytes"
+	case StringKind:
+		return "string"
+	case FixedKind:
+		return "fixed"
+	case UfixedKind:
+		return "ufixed"
+	case ArrayKind:
+		return "array"
+	case SliceKind:
+		return "slice"
+	case TupleKind:
+		return "tuple"
+	default:
+		return "kind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// Type is the parsed representation of an ABI type string.
+type Type struct {
+	Kind       Kind
+	Size       int      // bits for int, uint, fixed and ufixed; bytes for bytesN
+	Decimals   int      // fractional places for fixed and ufixed
+	Length     int      // length for fixed length arrays
+	Elem       *Type    // element type for arrays
+	Components []*Type  // component types for tuples
+	Names      []string // component names for tuples, nil if not given
+
+	layout *typeLayout // cached layout, set by ParseType
+}
+
+// typeLayout caches the encoding layout of a parsed type.
+type typeLayout struct {
+	dynamic  bool
+	headSize int
+}
+
+// ParseType parses given type string (i.e. `(uint8,(address,bytes)[])[2]`)
+// into a Type tree. The aliases `int`, `uint`, `fixed` and `ufixed` are
+// accepted and resolved to their canonical types. Tuple components may
+// be named, i.e. `(address owner,uint256 amount)`.
+func ParseType(typeStr string) (*Type, error) {
+	p := &typeParser{src: typeStr}
+
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.src) {
+		return nil, p.errorf("unexpected character %q", p.src[p.pos])
+	}
+
+	return t, nil
+}
+
+// String returns the canonical type string.
+func (t *Type) String() string {
+	switch t.Kind {
+	case IntKind, UintKind:
+		return t.Kind.String() + strconv.Itoa(t.Size)
+	case FixedBytesKind:
+		return "bytes" + strconv.Itoa(t.Size)
+	case FixedKind, UfixedKind:
+		return t.Kind.String() + strconv.Itoa(t.Size) + "x" + strconv.Itoa(t.Decimals)
+	case ArrayKind:
+		return t.Elem.String() + "[" + strconv.Itoa(t.Length) + "]"
+	case SliceKind:
+		return t.Elem.String() + "[]"
+	case TupleKind:
+		components := make([]string, len(t.Components))
+		for i, component := range t.Components {
+			components[i] = component.String()
+		}
+		return "(" + strings.Join(components, ",") + ")"
+	default:
+		return t.Kind.String()
+	}
+}
+
+// IsDynamic checks whether the type is dynamic, i.e. if it is either
+// bytes, string, a dynamic length array or contains a dynamic type.
+func (t *Type) IsDynamic() bool {
+	if t.layout != nil {
+		return t.layout.dynamic
+	}
+
+	switch t.Kind {
+	case BytesKind, StringKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.IsDynamic()
+	case TupleKind:
+		for _, component := range t.Components {
+			if component.IsDynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// headSize returns the number of bytes the type takes in the head
+// of an encoding.
+func (t *Type) headSize() int {
+	if t.layout != nil {
+		return t.layout.headSize
+	}
+
+	if t.IsDynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case ArrayKind:
+		return t.Length * t.Elem.headSize()
+	case TupleKind:
+		size := 0
+		for _, component := range t.Components {
+			size += component.headSize()
+		}
+		return size
+	default:
+		return 32
+	}
+}
+
+// cacheLayout computes and caches the encoding layout of the type.
+// Component and element types must be cached beforehand.
+func (t *Type) cacheLayout() *Type {
+	t.layout = &typeLayout{dynamic: t.IsDynamic(), headSize: t.headSize()}
+	return t
+}
+
+// packedSize returns the number of bytes the type takes in packed
+// encoding. Returns 0 for types without a fixed packed size.
+func (t *Type) packedSize() int {
+	switch t.Kind {
+	case IntKind, UintKind, FixedKind, UfixedKind:
+		return t.Size / 8
+	case FixedBytesKind:
+		return t.Size
+	case AddressKind:
+		return 20
+	case BoolKind:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// isElementary checks whether the type is neither an array nor a tuple.
+func (t *Type) isElementary() bool {
+	return t.Kind != ArrayKind && t.Kind != SliceKind && t.Kind != TupleKind
+}
+
+// parseTypes parses all given type strings.
+func parseTypes(typeStrs []string) ([]*Type, error) {
+	types := make([]*Type, len(typeStrs))
+	for i, typeStr := range typeStrs {
+		t, err := ParseType(typeStr)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = t
+	}
+
+	return types, nil
+}
+
+// repeatType returns a slice with given type repeated n times.
+func repeatType(t *Type, n int) []*Type {
+	types := make([]*Type, n)
+	for i := range types {
+		types[i] = t
+	}
+
+	return types
+}
+
+// maxArrayHeadSize limits the head size of fixed length arrays,
+// preventing overflows in the layout computation.
+const maxArrayHeadSize = math.MaxInt32
+
+// typeParser is a recursive descent parser for type strings.
+type typeParser struct {
+	src string
+	pos int
+}
+
+// errorf returns an error pointing to the current parser position.
+func (p *typeParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid type %q at position %d: %s", p.src, p.pos, fmt.Sprintf(format, args...))
+}
+
+// parseType parses a tuple or an elementary type followed by
+// any number of array suffixes.
+func (p *typeParser) parseType() (*Type, error) {
+	var t *Type
+	var err error
+	if p.pos < len(p.src) && p.src[p.pos] == '(' {
+		t, err = p.parseTuple()
+	} else {
+		t, err = p.parseElementary()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for p.pos < len(p.src) && p.src[p.pos] == '[' {
+		if t.headSize() == 0 {
+			// elements without encoding would let any length be decoded from no data
+			return nil, p.errorf("invalid array of empty tuples")
+		}
+		p.pos++
+		start := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		if p.pos >= len(p.src) || p.src[p.pos] != ']' {
+			return nil, p.errorf("expected ']'")
+		}
+
+		if start == p.pos {
+			t = (&Type{Kind: SliceKind, Elem: t}).cacheLayout()
+		} else {
+			length, err := strconv.Atoi(p.src[start:p.pos])
+			if err != nil || length == 0 || length > maxArrayHeadSize/t.headSize() {
+				return nil, p.errorf("invalid array length %q", p.src[start:p.pos])
+			}
+			t = (&Type{Kind: ArrayKind, Length: length, Elem: t}).cacheLayout()
+		}
+		p.pos++
+	}
+
+	return t, nil
+}
+
+// parseTuple parses a parenthesized, comma separated list of types.
+func (p *typeParser) parseTuple() (*Type, error) {
+	p.pos++ // skip '('
+
+	t := &Type{Kind: TupleKind}
+	if p.pos < len(p.src) && p.src[p.pos] == ')' {
+		p.pos++
+		return t.cacheLayout(), nil
+	}
+
+	for {
+		component, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		t.Components = append(t.Components, component)
+
+		name, err := p.parseComponentName()
+		if err != nil {
+			return nil, err
+		}
+		if name != "" && t.Names == nil {
+			t.Names = make([]string, len(t.Components)-1)
+		}
+		if t.Names != nil {
+			t.Names = append(t.Names, name)
+		}
+
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("expected ')'")
+		}
+
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return t.cacheLayout(), nil
+		default:
+			return nil, p.errorf("unexpected character %q", p.src[p.pos])
+		}
+	}
+}
+
+// parseComponentName parses the optional name following a tuple
+// component type.
+func (p *typeParser) parseComponentName() (string, error) {
+	if p.pos >= len(p.src) || p.src[p.pos] != ' ' {
+		return "", nil
+	}
+
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && isIdentifierChar(p.src[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos || (p.src[start] >= '0' && p.src[start] <= '9') {
+		return "", p.errorf("expected component name")
+	}
+
+	return p.src[start:p.pos], nil
+}
+
+// parseElementary parses an elementary type name.
+func (p *typeParser) parseElementary() (*Type, error) {
+	start := p.pos
+	for p.pos < len(p.src) && isTypeNameChar(p.src[p.pos]) {
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+
+	if name == "" {
+		return nil, p.errorf("expected type name")
+	}
+
+	t, err := p.elementaryType(name, start)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.cacheLayout(), nil
+}
+
+// elementaryType returns the elementary type for given type name,
+// starting at given position.
+func (p *typeParser) elementaryType(name string, start int) (*Type, error) {
+	switch name {
+	case "address":
+		return &Type{Kind: AddressKind}, nil
+	case "bool":
+		return &Type{Kind: BoolKind}, nil
+	case "string":
+		return &Type{Kind: StringKind}, nil
+	case "bytes":
+		return &Type{Kind: BytesKind}, nil
+	case "int":
+		return &Type{Kind: IntKind, Size: 256}, nil
+	case "uint":
+		return &Type{Kind: UintKind, Size: 256}, nil
+	case "fixed":
+		return &Type{Kind: FixedKind, Size: 128, Decimals: 18}, nil
+	case "ufixed":
+		return &Type{Kind: UfixedKind, Size: 128, Decimals: 18}, nil
+	}
+
+	switch {
+	case strings.HasPrefix(name, "uint"):
+		bits, err := p.parseBits(name, name[4:])
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Kind: UintKind, Size: bits}, nil
+	case strings.HasPrefix(name, "int"):
+		bits, err := p.parseBits(name, name[3:])
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Kind: IntKind, Size: bits}, nil
+	case strings.HasPrefix(name, "bytes"):
+		size, err := strconv.Atoi(name[5:])
+		if err != nil || size < 1 || size > 32 || name[5] == '0' {
+			return nil, p.errorf("invalid byte size in %v", name)
+		}
+		return &Type{Kind: FixedBytesKind, Size: size}, nil
+	case strings.HasPrefix(name, "ufixed"):
+		bits, decimals, err := p.parseFixedSizes(name, name[6:])
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Kind: UfixedKind, Size: bits, Decimals: decimals}, nil
+	case strings.HasPrefix(name, "fixed"):
+		bits, decimals, err := p.parseFixedSizes(name, name[5:])
+		if err != nil {
+			return nil, err
+		}
+		return &Type{Kind: FixedKind, Size: bits, Decimals: decimals}, nil
+	}
+
+	p.pos = start
+	return nil, p.errorf("unknown type %v", name)
+}
+
+// parseBits parses the bit size of integer and fixed point types.
+// Valid sizes are multiples of 8 from 8 up to 256.
+func (p *typeParser) parseBits(name string, bitsStr string) (int, error) {
+	bits, err := strconv.Atoi(bitsStr)
+	if err != nil || bitsStr[0] == '0' || bits < 8 || bits > 256 || bits%8 != 0 {
+		return 0, p.errorf("invalid bits value in %v", name)
+	}
+
+	return bits, nil
+}
+
+// parseFixedSizes parses the `MxN` suffix of fixed point types.
+// N must be between 0 and 80.
+func (p *typeParser) parseFixedSizes(name string, sizesStr string) (int, int, error) {
+	sizes := strings.Split(sizesStr, "x")
+	if len(sizes) != 2 || sizes[0] == "" || sizes[1] == "" {
+		return 0, 0, p.errorf("invalid fixed point sizes in %v", name)
+	}
+
+	bits, err := p.parseBits(name, sizes[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	decimals, err := strconv.Atoi(sizes[1])
+	if err != nil || (len(sizes[1]) > 1 && sizes[1][0] == '0') || decimals < 0 || decimals > 80 {
+		return 0, 0, p.errorf("invalid frac. places in %v", name)
+	}
+
+	return bits, decimals, nil
+}
+
+// isTypeNameChar checks whether given character can be part of an
+// elementary type name.
+func isTypeNameChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
+
+// isIdentifierChar checks whether given character can be part of a
+// Solidity identifier.
+func isIdentifierChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '$'
+}
@@ -0,0 +1,39 @@
+package abi_test
+
+import (
+	"fmt"
+
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleParseType() {
+	typeStr := "(uint8,(address,bytes)[],(uint,bool))[2]"
+	t, err := abi.ParseType(typeStr)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(t, t.Kind, t.Length, t.IsDynamic())
+	fmt.Println(t.Elem.Components[0].Kind, t.Elem.Components[0].Size)
+	fmt.Println(t.Elem.Components[1], t.Elem.Components[2])
+
+	// Output:
+	// (uint8,(address,bytes)[],(uint256,bool))[2] array 2 true
+	// uint 8
+	// (address,bytes)[] (uint256,bool)
+}
+
+func ExampleParseType_invalid() {
+	for _, typeStr := range []string{"uint7", "(address,bytes", "bytes33", "uint256[x]", "(address,,bool)", "()[100000000]"} {
+		_, err := abi.ParseType(typeStr)
+		fmt.Println(err)
+	}
+
+	// Output:
+	// invalid type "uint7" at position 5: invalid bits value in uint7
+	// invalid type "(address,bytes" at position 14: expected ')'
+	// invalid type "bytes33" at position 7: invalid byte size in bytes33
+	// invalid type "uint256[x]" at position 8: expected ']'
+	// invalid type "(address,,bool)" at position 9: expected type name
+	// invalid type "()[100000000]" at position 2: invalid array of empty tuples
+}
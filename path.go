@@ -0,0 +1,158 @@
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathStep is a step of a path expression.
+type pathStep struct {
+	index    int    // index of an element or component, when name is empty and wildcard is false
+	name     string // name of a component
+	wildcard bool   // all elements or components
+}
+
+// Extract extracts the values matching a path expression from the
+// calldata of a function, i.e. `args[1][*].amount` from the calldata
+// of `swap(address pool,(address token,uint256 amount)[] legs)`.
+// Values are decoded like Decode.
+//
+// The path starts with `args`, the function arguments, followed by:
+//   - `[N]`: the element or component at index N;
+//   - `[*]`: all the elements or components;
+//   - `.name`: the component or argument with given name, when named
+//     in the signature.
+func Extract(funcSignature string, path string, data []byte) ([]any, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// GetSigTypes drops the parameter names that `.name` steps need
+	name, params, err := parseNamedSignature(funcSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := DecodeWithSignature(name+params.String(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	types := []*Type{params}
+	matches := []any{values}
+	for _, step := range steps {
+		types, matches, err = applyPathStep(step, types, matches)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %v", path, err)
+		}
+	}
+
+	return matches, nil
+}
+
+// applyPathStep applies a path step to the matching values of given
+// types.
+func applyPathStep(step pathStep, types []*Type, values []any) ([]*Type, []any, error) {
+	var nextTypes []*Type
+	var nextValues []any
+	for i, t := range types {
+		if t.isElementary() {
+			return nil, nil, fmt.Errorf("cannot select %v of %v", step, t)
+		}
+
+		elems, ok := values[i].([]any)
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid value type for %v: %T", t, values[i])
+		}
+
+		elemTypes, err := elementTypes(t, len(elems))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		index := step.index
+		switch {
+		case step.wildcard:
+			nextTypes = append(nextTypes, elemTypes...)
+			nextValues = append(nextValues, elems...)
+			continue
+		case step.name != "":
+			index = -1
+			if t.Kind == TupleKind {
+				index = indexOf(t.Names, step.name)
+			}
+			if index == -1 {
+				return nil, nil, fmt.Errorf("no component named %q in %v", step.name, t)
+			}
+		case index >= len(elems):
+			return nil, nil, fmt.Errorf("index %d out of range for %v of length %d", index, t, len(elems))
+		}
+
+		nextTypes = append(nextTypes, elemTypes[index])
+		nextValues = append(nextValues, elems[index])
+	}
+
+	return nextTypes, nextValues, nil
+}
+
+// parsePath parses a path expression, i.e. `args[1][*].amount`.
+func parsePath(path string) ([]pathStep, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(path), "args")
+	if !ok {
+		return nil, fmt.Errorf("invalid path %q: must start with args", path)
+	}
+
+	var steps []pathStep
+	for rest != "" {
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid path %q: missing ]", path)
+			}
+
+			selector := rest[1:end]
+			rest = rest[end+1:]
+			if selector == "*" {
+				steps = append(steps, pathStep{wildcard: true})
+				continue
+			}
+
+			index, err := strconv.Atoi(selector)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid path %q: invalid index %q", path, selector)
+			}
+			steps = append(steps, pathStep{index: index})
+		case '.':
+			end := 1
+			for end < len(rest) && isIdentifierChar(rest[end]) {
+				end++
+			}
+
+			name := rest[1:end]
+			rest = rest[end:]
+			if name == "" {
+				return nil, fmt.Errorf("invalid path %q: missing name after .", path)
+			}
+			steps = append(steps, pathStep{name: name})
+		default:
+			return nil, fmt.Errorf("invalid path %q: unexpected %q", path, rest[0])
+		}
+	}
+
+	return steps, nil
+}
+
+// String returns the path expression of the step.
+func (s pathStep) String() string {
+	switch {
+	case s.wildcard:
+		return "[*]"
+	case s.name != "":
+		return "." + s.name
+	default:
+		return "[" + strconv.Itoa(s.index) + "]"
+	}
+}
@@ -0,0 +1,61 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleExtract() {
+	funcSignature := "swap(address pool,(address token,uint256 amount)[] legs)"
+	calldata, err := abi.EncodeWithSignature(
+		"swap(address,(address,uint256)[])",
+		common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"),
+		[]any{
+			[]any{common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), big.NewInt(1000)},
+			[]any{common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), big.NewInt(2000)},
+		},
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for _, path := range []string{"args[1][*].amount", "args.legs[0].token", "args[0]", "args.legs[2]"} {
+		values, err := abi.Extract(funcSignature, path, calldata)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		fmt.Println(values)
+	}
+
+	// Output:
+	// [1000 2000]
+	// [0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48]
+	// [0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640]
+	// invalid path "args.legs[2]": index 2 out of range for (address,uint256)[] of length 2
+}
+
+func ExampleExtract_spacedSignature() {
+	funcSignature := "swap(address pool, (address token, uint256 amount)[] legs)"
+	calldata, err := abi.EncodeWithSignature(
+		"swap(address,(address,uint256)[])",
+		common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"),
+		[]any{[]any{common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), big.NewInt(1000)}},
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	values, err := abi.Extract(funcSignature, "args.legs[0].amount", calldata)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(values)
+
+	// Output: [1000]
+}
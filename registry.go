@@ -0,0 +1,218 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SelectorRegistry indexes function and custom error signatures by
+// selector, and event signatures by topic, to identify calldata, revert
+// data and logs of contracts whose ABI is unknown.
+type SelectorRegistry struct {
+	functions map[[4]byte][]string
+	events    map[common.Hash][]string
+}
+
+// DecodedCall is a candidate decoding of calldata found by
+// SelectorRegistry.DecodeAny.
+type DecodedCall struct {
+	Signature string
+	Args      []any
+}
+
+// NewSelectorRegistry creates an empty selector registry.
+func NewSelectorRegistry() *SelectorRegistry {
+	return &SelectorRegistry{
+		functions: make(map[[4]byte][]string),
+		events:    make(map[common.Hash][]string),
+	}
+}
+
+// DefaultSelectorRegistry creates a selector registry loaded with the
+// bundled offline snapshot of common signatures, i.e. ERC-20, ERC-721,
+// ERC-1155, Uniswap, Safe and Multicall functions, events and errors.
+func DefaultSelectorRegistry() *SelectorRegistry {
+	r := NewSelectorRegistry()
+	if err := r.AddSignatures(commonFunctionSignatures...); err != nil {
+		panic(err)
+	}
+	if err := r.AddEventSignatures(commonEventSignatures...); err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// AddSignatures adds function or custom error signatures, i.e.
+// `transfer(address,uint256)`. Parameter names and output parameters
+// are allowed and ignored, i.e. `balanceOf(address owner)(uint256)`.
+func (r *SelectorRegistry) AddSignatures(signatures ...string) error {
+	for _, signature := range signatures {
+		canonical, err := canonicalSignature(signature)
+		if err != nil {
+			return err
+		}
+
+		var selector [4]byte
+		copy(selector[:], EncodeSignature(canonical))
+		r.functions[selector] = appendUnique(r.functions[selector], canonical)
+	}
+
+	return nil
+}
+
+// AddEventSignatures adds event signatures, i.e.
+// `Transfer(address,address,uint256)`. Parameter names and `indexed`
+// keywords are allowed and ignored.
+func (r *SelectorRegistry) AddEventSignatures(signatures ...string) error {
+	for _, signature := range signatures {
+		event, err := ParseEvent(signature)
+		if err != nil {
+			return err
+		}
+
+		topic := common.BytesToHash(event.Topic)
+		r.events[topic] = appendUnique(r.events[topic], event.Signature)
+	}
+
+	return nil
+}
+
+// AddContract adds the functions, events and custom errors of given
+// contract.
+func (r *SelectorRegistry) AddContract(contract *Contract) {
+	for signature, method := range contract.Functions {
+		var selector [4]byte
+		copy(selector[:], method.Selector)
+		r.functions[selector] = appendUnique(r.functions[selector], signature)
+	}
+
+	for signature, customError := range contract.Errors {
+		var selector [4]byte
+		copy(selector[:], customError.Selector)
+		r.functions[selector] = appendUnique(r.functions[selector], signature)
+	}
+
+	for signature, event := range contract.Events {
+		topic := common.BytesToHash(event.Topic)
+		r.events[topic] = appendUnique(r.events[topic], signature)
+	}
+}
+
+// LoadABI adds the functions, events and custom errors of a contract
+// JSON ABI or artifact. Calls LoadContract function.
+func (r *SelectorRegistry) LoadABI(jsonData []byte) error {
+	contract, err := LoadContract(jsonData)
+	if err != nil {
+		return err
+	}
+
+	r.AddContract(contract)
+	return nil
+}
+
+// Lookup returns the function and custom error signatures matching
+// the first 4 bytes of given selector or calldata, sorted.
+func (r *SelectorRegistry) Lookup(selector []byte) []string {
+	if len(selector) < 4 {
+		return nil
+	}
+
+	var key [4]byte
+	copy(key[:], selector)
+	return sortedCopy(r.functions[key])
+}
+
+// LookupEvent returns the event signatures matching given topic, i.e.
+// the first topic of a non-anonymous log, sorted.
+func (r *SelectorRegistry) LookupEvent(topic []byte) []string {
+	if len(topic) != 32 {
+		return nil
+	}
+
+	return sortedCopy(r.events[common.BytesToHash(topic)])
+}
+
+// DecodeAny decodes calldata with every candidate signature of its
+// selector, keeping those which decode cleanly and re-encode to the
+// exact same calldata. Returns an error when no candidate is left.
+func (r *SelectorRegistry) DecodeAny(data []byte) ([]DecodedCall, error) {
+	candidates := r.Lookup(data)
+	if len(candidates) == 0 {
+		if len(data) < 4 {
+			return nil, fmt.Errorf("data byte size is too short for selector. Length: %d", len(data))
+		}
+		return nil, fmt.Errorf("unknown selector 0x%x", data[:4])
+	}
+
+	var calls []DecodedCall
+	for _, signature := range candidates {
+		args, err := DecodeWithSignature(signature, data)
+		if err != nil {
+			continue
+		}
+
+		// untyped values hold addresses as strings, re-encode typed ones
+		typeStrs, _ := GetSigTypes(signature)
+		values, err := DecodeTyped(typeStrs, data[4:])
+		if err != nil {
+			continue
+		}
+
+		encoded, err := Encode(typeStrs, values...)
+		if err != nil || !bytes.Equal(encoded, data[4:]) {
+			continue
+		}
+
+		calls = append(calls, DecodedCall{Signature: signature, Args: args})
+	}
+
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("no signature of selector 0x%x matches data: %v", data[:4], strings.Join(candidates, ", "))
+	}
+
+	return calls, nil
+}
+
+// canonicalSignature returns the canonical form of a function or
+// custom error signature, i.e. `transfer(address to, uint amount)(bool)`
+// into `transfer(address,uint256)`. Calls ParseFunction function.
+func canonicalSignature(signature string) (string, error) {
+	inputSig, _, _, err := splitSignature(strings.TrimSpace(signature))
+	if err != nil {
+		return "", fmt.Errorf("invalid signature %q: %v", signature, err)
+	}
+
+	method, err := ParseFunction(inputSig)
+	if err != nil {
+		return "", err
+	}
+
+	return method.Signature, nil
+}
+
+// appendUnique appends s to list unless already present.
+func appendUnique(list []string, s string) []string {
+	for _, item := range list {
+		if item == s {
+			return list
+		}
+	}
+
+	return append(list, s)
+}
+
+// sortedCopy returns a sorted copy of list.
+func sortedCopy(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+
+	sorted := append([]string(nil), list...)
+	sort.Strings(sorted)
+	return sorted
+}
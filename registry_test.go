@@ -0,0 +1,75 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleSelectorRegistry_Lookup() {
+	registry := abi.DefaultSelectorRegistry()
+	// selector collision with `transferFrom(address,address,uint256)`
+	err := registry.AddSignatures("gasprice_bit_ether(int128)")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(registry.Lookup(common.FromHex("0x23b872dd")))
+	fmt.Println(registry.Lookup(common.FromHex("0xa9059cbb")))
+
+	// Output:
+	// [gasprice_bit_ether(int128) transferFrom(address,address,uint256)]
+	// [transfer(address,uint256)]
+}
+
+func ExampleSelectorRegistry_DecodeAny() {
+	registry := abi.DefaultSelectorRegistry()
+	err := registry.AddSignatures("gasprice_bit_ether(int128)")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	data, err := abi.EncodeWithSignature(
+		"transferFrom(address,address,uint256)",
+		common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"),
+		common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"),
+		big.NewInt(1000),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	calls, err := registry.DecodeAny(data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for _, call := range calls {
+		fmt.Println(call.Signature, call.Args)
+	}
+
+	// Output:
+	// transferFrom(address,address,uint256) [0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 1000]
+}
+
+func ExampleSelectorRegistry_LookupEvent() {
+	registry := abi.NewSelectorRegistry()
+	err := registry.LoadABI([]byte(`[
+		{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+			{"name":"from","type":"address","indexed":true},
+			{"name":"to","type":"address","indexed":true},
+			{"name":"value","type":"uint256","indexed":false}
+		]}
+	]`))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	topic := common.FromHex("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	fmt.Println(registry.LookupEvent(topic))
+
+	// Output:
+	// [Transfer(address,address,uint256)]
+}
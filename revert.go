@@ -0,0 +1,153 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RevertKind identifies the kind of a revert.
+type RevertKind int
+
+const (
+	RevertEmpty  RevertKind = iota // revert without data, i.e. `revert()` or `require(false)`
+	RevertError                    // Error(string), i.e. `require(false, "reason")`
+	RevertPanic                    // Panic(uint256), i.e. failed `assert` or arithmetic overflow
+	RevertCustom                   // custom error, i.e. `revert InsufficientBalance(10, 20)`
+)
+
+// errorSelector is the selector of `Error(string)`.
+var errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+// panicSelector is the selector of `Panic(uint256)`.
+var panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+
+// panicReasons maps Solidity panic codes to their meaning.
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "too much memory allocated",
+	0x51: "call to zero-initialized internal function",
+}
+
+// RevertReason is decoded revert data.
+type RevertReason struct {
+	Kind      RevertKind
+	Signature string   // `Error(string)`, `Panic(uint256)` or the custom error signature
+	Message   string   // reason string for Error(string) and the meaning of the panic code for Panic(uint256)
+	PanicCode *big.Int // panic code for Panic(uint256)
+	Args      []any    // decoded arguments
+}
+
+// Error implements the error interface.
+func (r *RevertReason) Error() string {
+	switch r.Kind {
+	case RevertEmpty:
+		return "execution reverted"
+	case RevertError:
+		return "execution reverted: " + r.Message
+	case RevertPanic:
+		return fmt.Sprintf("execution reverted: panic 0x%x (%v)", r.PanicCode, r.Message)
+	default:
+		args := make([]string, len(r.Args))
+		for i, arg := range r.Args {
+			args[i] = fmt.Sprint(arg)
+		}
+		name, _, _ := strings.Cut(r.Signature, "(")
+		return "execution reverted: " + name + "(" + strings.Join(args, ", ") + ")"
+	}
+}
+
+// PanicReason returns the Solidity meaning of given panic code.
+func PanicReason(code *big.Int) string {
+	if code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return reason
+		}
+	}
+
+	return "unknown panic code"
+}
+
+// DecodeRevert decodes revert data returned by a failed call. Recognizes
+// `Error(string)`, `Panic(uint256)` and given custom error signatures
+// (i.e. `InsufficientBalance(uint256,uint256)`).
+func DecodeRevert(data []byte, customErrors ...string) (*RevertReason, error) {
+	if len(data) == 0 {
+		return &RevertReason{Kind: RevertEmpty}, nil
+	}
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("revert data is too short: 0x%x", data)
+	}
+
+	selector := data[:4]
+	switch {
+	case bytes.Equal(selector, errorSelector):
+		decoded, err := Decode([]string{"string"}, data[4:])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding Error(string) revert: %v", err)
+		}
+
+		return &RevertReason{
+			Kind:      RevertError,
+			Signature: "Error(string)",
+			Message:   decoded[0].(string),
+			Args:      decoded,
+		}, nil
+	case bytes.Equal(selector, panicSelector):
+		decoded, err := Decode([]string{"uint256"}, data[4:])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding Panic(uint256) revert: %v", err)
+		}
+
+		code := decoded[0].(*big.Int)
+		return &RevertReason{
+			Kind:      RevertPanic,
+			Signature: "Panic(uint256)",
+			Message:   PanicReason(code),
+			PanicCode: code,
+			Args:      decoded,
+		}, nil
+	}
+
+	for _, customError := range customErrors {
+		if !bytes.Equal(selector, EncodeSignature(customError)) {
+			continue
+		}
+
+		decoded, err := DecodeWithSignature(customError, data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %v revert: %v", customError, err)
+		}
+
+		return &RevertReason{
+			Kind:      RevertCustom,
+			Signature: customError,
+			Args:      decoded,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown revert selector: %v", common.Bytes2Hex(selector))
+}
+
+// DecodeRevert decodes revert data returned by a failed call to the
+// contract, matching the contract custom errors.
+// Calls DecodeRevert function.
+func (c *Contract) DecodeRevert(data []byte) (*RevertReason, error) {
+	customErrors := make([]string, 0, len(c.Errors))
+	for signature := range c.Errors {
+		customErrors = append(customErrors, signature)
+	}
+
+	return DecodeRevert(data, customErrors...)
+}
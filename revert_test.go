@@ -0,0 +1,66 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleDecodeRevert() {
+	data := common.Hex2Bytes("08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001a4e6f7420656e6f7567682045746865722070726f76696465642e000000000000")
+
+	reason, err := abi.DecodeRevert(data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(reason.Kind == abi.RevertError, reason.Message)
+	fmt.Println(reason)
+
+	// Output:
+	// true Not enough Ether provided.
+	// execution reverted: Not enough Ether provided.
+}
+
+func ExampleDecodeRevert_panic() {
+	data := common.Hex2Bytes("4e487b710000000000000000000000000000000000000000000000000000000000000011")
+
+	reason, err := abi.DecodeRevert(data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(reason.PanicCode, reason.Message)
+
+	// Output: 17 arithmetic overflow or underflow
+}
+
+func ExampleDecodeRevert_custom() {
+	customError := "InsufficientBalance(uint256,uint256)"
+	data, err := abi.EncodeWithSignature(customError, big.NewInt(10), big.NewInt(20))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	reason, err := abi.DecodeRevert(data, "Unauthorized(address)", customError)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(reason.Signature, reason.Args)
+	fmt.Println(reason)
+
+	// Output:
+	// InsufficientBalance(uint256,uint256) [10 20]
+	// execution reverted: InsufficientBalance(10, 20)
+}
+
+func ExampleRevertReason_Error() {
+	reason := &abi.RevertReason{Kind: abi.RevertCustom, Signature: "Unauthorized", Args: []any{"0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"}}
+
+	fmt.Println(reason.Error())
+
+	// Output: execution reverted: Unauthorized(0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789)
+}
@@ -0,0 +1,106 @@
+package abi
+
+import "fmt"
+
+// Schema is a compiled list of argument types. Type strings are
+// parsed once and the head layout is computed upfront, so encoding
+// and decoding many payloads with the same types skips that work.
+// A Schema is immutable and safe for concurrent use.
+type Schema struct {
+	typeStrs   []string
+	types      []*Type
+	offsets    []int // head offset of each argument
+	headLength int
+}
+
+// NewSchema compiles given type strings into a Schema.
+func NewSchema(typeStrs []string) (*Schema, error) {
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{
+		typeStrs: make([]string, len(types)),
+		types:    types,
+		offsets:  make([]int, len(types)),
+	}
+	for i, t := range types {
+		schema.typeStrs[i] = t.String()
+		schema.offsets[i] = schema.headLength
+		schema.headLength += t.headSize()
+	}
+
+	return schema, nil
+}
+
+// NewSchemaFromSignature compiles the input parameter types of given
+// function signature into a Schema.
+// Calls GetSigTypes function.
+func NewSchemaFromSignature(funcSig string) (*Schema, error) {
+	typeStrs, err := GetSigTypes(funcSig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSchema(typeStrs)
+}
+
+// Types returns the canonical type strings of the schema.
+func (s *Schema) Types() []string {
+	return append([]string(nil), s.typeStrs...)
+}
+
+// Encode encodes given arguments based on the schema types.
+func (s *Schema) Encode(values ...any) ([]byte, error) {
+	if len(s.types) != len(values) {
+		return []byte{}, fmt.Errorf(
+			"schema types and values must have the same length. types: %v (length %v), values: %v (length %v)",
+			s.typeStrs,
+			len(s.typeStrs),
+			values,
+			len(values),
+		)
+	}
+
+	return encodeTuple(s.types, values)
+}
+
+// Decode decodes bytecode to the schema types.
+func (s *Schema) Decode(data []byte) ([]any, error) {
+	return s.decode(decoder{}, data)
+}
+
+// DecodeTyped decodes bytecode to the schema types, returning the
+// same values as DecodeTyped.
+func (s *Schema) DecodeTyped(data []byte) ([]any, error) {
+	return s.decode(decoder{typed: true}, data)
+}
+
+// DecodeStrict decodes bytecode to the schema types, rejecting
+// non-canonical encodings as DecodeStrict does.
+func (s *Schema) DecodeStrict(data []byte) ([]any, error) {
+	return s.decode(decoder{strict: true}, data)
+}
+
+// decode decodes bytecode to the schema types with given decoder.
+func (s *Schema) decode(d decoder, data []byte) ([]any, error) {
+	if len(data) < s.headLength {
+		return []any{}, fmt.Errorf("data byte size is too short for schema %v. Length: %d, head length: %d", s.typeStrs, len(data), s.headLength)
+	}
+
+	d = d.withStrings()
+	result := make([]any, len(s.types))
+	end := s.headLength
+	for i, t := range s.types {
+		val, tailEnd, err := d.decodeAt(t, data, s.offsets[i], end)
+		if err != nil {
+			return []any{}, err
+		}
+
+		result[i] = val
+		end = max(end, tailEnd)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleNewSchema() {
+	schema, err := abi.NewSchema([]string{"address", "uint256[]", "bytes", "(address,uint256[],bytes)[]"})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	addressParam := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	uint256ArrParam := []any{big.NewInt(100), big.NewInt(352)}
+	bytesParam := []byte("arbitrary byte array...")
+	tupleArrParam := []any{
+		[]any{&addressParam, uint256ArrParam, bytesParam},
+	}
+
+	encoded, err := schema.Encode(&addressParam, uint256ArrParam, bytesParam, tupleArrParam)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	decoded, err := schema.Decode(encoded)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(decoded)
+
+	// Output: [0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 [100 352] [97 114 98 105 116 114 97 114 121 32 98 121 116 101 32 97 114 114 97 121 46 46 46] [[0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 [100 352] [97 114 98 105 116 114 97 114 121 32 98 121 116 101 32 97 114 114 97 121 46 46 46]]]]
+}
+
+func ExampleNewSchemaFromSignature() {
+	schema, err := abi.NewSchemaFromSignature("transfer(address,uint)")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(schema.Types())
+
+	// Output: [address uint256]
+}
@@ -0,0 +1,150 @@
+package abi
+
+// commonFunctionSignatures is the bundled snapshot of common function
+// and custom error signatures, loaded by DefaultSelectorRegistry.
+var commonFunctionSignatures = []string{
+	// ERC-20
+	"name()",
+	"symbol()",
+	"decimals()",
+	"totalSupply()",
+	"balanceOf(address)",
+	"transfer(address,uint256)",
+	"transferFrom(address,address,uint256)",
+	"approve(address,uint256)",
+	"allowance(address,address)",
+	"increaseAllowance(address,uint256)",
+	"decreaseAllowance(address,uint256)",
+	"mint(address,uint256)",
+	"burn(uint256)",
+	"burnFrom(address,uint256)",
+
+	// ERC-2612 permit
+	"permit(address,address,uint256,uint256,uint8,bytes32,bytes32)",
+	"nonces(address)",
+	"DOMAIN_SEPARATOR()",
+
+	// WETH
+	"deposit()",
+	"withdraw(uint256)",
+
+	// ERC-721
+	"ownerOf(uint256)",
+	"safeTransferFrom(address,address,uint256)",
+	"safeTransferFrom(address,address,uint256,bytes)",
+	"setApprovalForAll(address,bool)",
+	"getApproved(uint256)",
+	"isApprovedForAll(address,address)",
+	"tokenURI(uint256)",
+	"supportsInterface(bytes4)",
+
+	// ERC-1155
+	"balanceOf(address,uint256)",
+	"balanceOfBatch(address[],uint256[])",
+	"safeTransferFrom(address,address,uint256,uint256,bytes)",
+	"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)",
+	"uri(uint256)",
+
+	// Ownable
+	"owner()",
+	"transferOwnership(address)",
+	"renounceOwnership()",
+
+	// Uniswap V2
+	"factory()",
+	"token0()",
+	"token1()",
+	"getReserves()",
+	"getPair(address,address)",
+	"createPair(address,address)",
+	"swap(uint256,uint256,address,bytes)",
+	"getAmountsOut(uint256,address[])",
+	"getAmountsIn(uint256,address[])",
+	"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)",
+	"swapTokensForExactTokens(uint256,uint256,address[],address,uint256)",
+	"swapExactETHForTokens(uint256,address[],address,uint256)",
+	"swapExactTokensForETH(uint256,uint256,address[],address,uint256)",
+	"addLiquidity(address,address,uint256,uint256,uint256,uint256,address,uint256)",
+	"addLiquidityETH(address,uint256,uint256,uint256,address,uint256)",
+	"removeLiquidity(address,address,uint256,uint256,uint256,address,uint256)",
+	"removeLiquidityETH(address,uint256,uint256,uint256,address,uint256)",
+
+	// Uniswap V3
+	"slot0()",
+	"getPool(address,address,uint24)",
+	"exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+	"exactInput((bytes,address,uint256,uint256,uint256))",
+	"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+	"exactOutput((bytes,address,uint256,uint256,uint256))",
+	"multicall(bytes[])",
+	"multicall(uint256,bytes[])",
+
+	// Uniswap Universal Router
+	"execute(bytes,bytes[])",
+	"execute(bytes,bytes[],uint256)",
+
+	// Safe
+	"setup(address[],uint256,address,bytes,address,address,uint256,address)",
+	"execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)",
+	"getTransactionHash(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,uint256)",
+	"getOwners()",
+	"getThreshold()",
+	"nonce()",
+	"addOwnerWithThreshold(address,uint256)",
+	"removeOwner(address,address,uint256)",
+	"changeThreshold(uint256)",
+	"enableModule(address)",
+	"multiSend(bytes)",
+
+	// Multicall
+	"aggregate((address,bytes)[])",
+	"tryAggregate(bool,(address,bytes)[])",
+	"aggregate3((address,bool,bytes)[])",
+
+	// errors
+	"Error(string)",
+	"Panic(uint256)",
+	"ERC20InsufficientBalance(address,uint256,uint256)",
+	"ERC20InsufficientAllowance(address,uint256,uint256)",
+	"ERC721NonexistentToken(uint256)",
+	"OwnableUnauthorizedAccount(address)",
+}
+
+// commonEventSignatures is the bundled snapshot of common event
+// signatures, loaded by DefaultSelectorRegistry.
+var commonEventSignatures = []string{
+	// ERC-20 and ERC-721
+	"Transfer(address,address,uint256)",
+	"Approval(address,address,uint256)",
+	"ApprovalForAll(address,address,bool)",
+
+	// ERC-1155
+	"TransferSingle(address,address,address,uint256,uint256)",
+	"TransferBatch(address,address,address,uint256[],uint256[])",
+	"URI(string,uint256)",
+
+	// WETH
+	"Deposit(address,uint256)",
+	"Withdrawal(address,uint256)",
+
+	// Ownable and proxies
+	"OwnershipTransferred(address,address)",
+	"Upgraded(address)",
+	"Initialized(uint8)",
+	"Initialized(uint64)",
+
+	// Uniswap V2
+	"PairCreated(address,address,address,uint256)",
+	"Mint(address,uint256,uint256)",
+	"Burn(address,uint256,uint256,address)",
+	"Swap(address,uint256,uint256,uint256,uint256,address)",
+	"Sync(uint112,uint112)",
+
+	// Uniswap V3
+	"PoolCreated(address,address,uint24,int24,address)",
+	"Swap(address,address,int256,int256,uint160,uint128,int24)",
+
+	// Safe
+	"ExecutionSuccess(bytes32,uint256)",
+	"ExecutionFailure(bytes32,uint256)",
+}
@@ -2,7 +2,6 @@ package abi
 
 import (
 	"fmt"
-	"math"
 	"math/big"
 )
 
@@ -14,23 +13,14 @@ type paramType struct {
 	Max        *big.Int // max value
 }
 
-// minusTwo big.Int for -2
-var minusTwo = big.NewInt(-2)
-
 // zero big.Int for 0
 var zero = big.NewInt(0)
 
-// floatZero big.Float for 0
-var floatZero = big.NewFloat(0)
-
 // one big.Int for 1
 var one = big.NewInt(1)
 
-// two big.Int for 2
-var two = big.NewInt(2)
-
-// floatTen big.Float for 10
-var floatTen = big.NewFloat(10)
+// twoTo256 big.Int for 2**256
+var twoTo256 = new(big.Int).Lsh(one, 256)
 
 // validCoreTypes maps type to its byte length and
 // minimum and maximum value restrictions.
@@ -39,7 +29,7 @@ var validCoreTypes = map[string]paramType{
 	"uint16":  {2, zero, big.NewInt(65535)},
 	"uint24":  {3, zero, big.NewInt(16777215)},
 	"uint32":  {4, zero, big.NewInt(4294967295)},
-	"uint40":  {5, zero, big.NewInt(1099511628000)},
+	"uint40":  {5, zero, big.NewInt(1099511627775)},
 	"uint48":  {6, zero, big.NewInt(281474976710655)},
 	"uint56":  {7, zero, big.NewInt(72057594037927935)},
 	"uint64":  {8, zero, convertStringToBigInt("18446744073709551615")},
@@ -147,47 +137,3 @@ func convertStringToBigInt(s string) *big.Int {
 
 	return bigInt
 }
-
-// computeSignedIntegerBounds computes the bound range
-// for signed integer based on its bits number.
-func computeSignedIntegerBounds(numBits int64) (*big.Int, *big.Int) {
-	resultLowest := new(big.Int)
-	resultLowest.Exp(minusTwo, big.NewInt(numBits-1), nil)
-
-	resultHighest := new(big.Int)
-	resultHighest.Exp(two, big.NewInt(numBits-1), nil)
-
-	return resultLowest, resultHighest.Sub(resultHighest, one)
-}
-
-// computeUnsignedIntegerBounds computes the bound range
-// for unsigned integer based on its bits number.
-func computeUnsignedIntegerBounds(numBits int64) (*big.Int, *big.Int) {
-	result := new(big.Int)
-	result.Exp(two, big.NewInt(numBits-1), nil)
-
-	return zero, result.Sub(result, one)
-}
-
-// computeSignedFixedBounds computes the bound range
-// for signed fixed point type based on its bits number
-// and number of decimals.
-func computeSignedFixedBounds(numbBits int64, fracPlaces float64) (*big.Float, *big.Float) {
-	lowest, highest := computeSignedIntegerBounds(numbBits)
-	floatHighest, _ := highest.Float64()
-	floatLowest, _ := lowest.Float64()
-
-	exp := math.Pow(10, -fracPlaces)
-
-	return big.NewFloat(floatLowest * exp), big.NewFloat(floatHighest * exp)
-}
-
-// computeUnsignedFixedBounds computes the bound range
-// for unsigned fixed point type based on its bits number
-// and number of decimals.
-func computeUnsignedFixedBounds(numbBits int64, fracPlaces float64) (*big.Float, *big.Float) {
-	_, highest := computeUnsignedIntegerBounds(numbBits)
-	floatHighest, _ := highest.Float64()
-
-	return floatZero, big.NewFloat(floatHighest * math.Pow(10, -fracPlaces))
-}
@@ -0,0 +1,406 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxLiteralExponent bounds the exponent of scientific notation
+// literals, i.e. `1e18`, well above the digits of any ABI integer.
+const maxLiteralExponent = 256
+
+// ParseValue parses a literal value of given type into the value
+// accepted by Encode, checking it fits the type. Literals are:
+//   - integers: decimal, 0x-prefixed hex or scientific notation, i.e. `-1`, `0xff` or `1.5e18`;
+//   - fixed point numbers: decimal, i.e. `-1.25`;
+//   - addresses, bytes and bytesN: 0x-prefixed hex;
+//   - booleans: `true` or `false`;
+//   - strings: as is, or quoted, i.e. `"a, b"`;
+//   - arrays: `[..]`, i.e. `[1,2,3]`;
+//   - tuples: `(..)`, i.e. `(0x5ff1...,[1,2],0x1234)`.
+func ParseValue(typeStr string, literal string) (any, error) {
+	t, err := ParseType(typeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLiteral(t, literal)
+}
+
+// ParseValues parses literal values of given types, as ParseValue does.
+func ParseValues(typeStrs []string, literals []string) ([]any, error) {
+	if len(typeStrs) != len(literals) {
+		return nil, fmt.Errorf("typeStrs and literals must have the same length. typeStrs: %v (length %v), literals: %v (length %v)", typeStrs, len(typeStrs), literals, len(literals))
+	}
+
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(types))
+	for i, t := range types {
+		values[i], err = parseLiteral(t, literals[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// ParseValuesJSON parses a JSON array holding one value per type into
+// the values accepted by Encode, checking they fit the types. Values
+// are JSON numbers or literal strings as accepted by ParseValue for
+// numbers, JSON booleans, JSON strings (or {"hex": "0x..."}) for strings,
+// JSON arrays for arrays and tuples, and JSON objects for tuples with
+// named components.
+func ParseValuesJSON(typeStrs []string, data json.RawMessage) ([]any, error) {
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw []any
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON values: %v", err)
+	}
+
+	if len(raw) != len(types) {
+		return nil, fmt.Errorf("typeStrs and JSON values must have the same length. typeStrs: %v (length %v), values length: %v", typeStrs, len(typeStrs), len(raw))
+	}
+
+	values := make([]any, len(types))
+	for i, t := range types {
+		values[i], err = parseJSONValue(t, raw[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// parseLiteral parses a literal value of given type.
+func parseLiteral(t *Type, literal string) (any, error) {
+	literal = strings.TrimSpace(literal)
+
+	switch t.Kind {
+	case ArrayKind, SliceKind, TupleKind:
+		open, closing := "[", "]"
+		if t.Kind == TupleKind {
+			open, closing = "(", ")"
+		}
+
+		if !strings.HasPrefix(literal, open) || !strings.HasSuffix(literal, closing) {
+			return nil, fmt.Errorf("invalid %v value %q: expected %v...%v", t, literal, open, closing)
+		}
+
+		elements, err := splitElements(literal[1 : len(literal)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %q: %v", t, literal, err)
+		}
+
+		types, err := elementTypes(t, len(elements))
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, len(elements))
+		for i, element := range elements {
+			values[i], err = parseLiteral(types[i], element)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return values, nil
+	case BoolKind:
+		value, err := strconv.ParseBool(literal)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %q", t, literal)
+		}
+		return value, nil
+	case StringKind:
+		if unquoted, err := strconv.Unquote(literal); err == nil {
+			return unquoted, nil
+		}
+		return literal, nil
+	default:
+		return parseElementaryLiteral(t, literal)
+	}
+}
+
+// parseJSONValue parses a value decoded from JSON, with numbers kept
+// as json.Number, of given type.
+func parseJSONValue(t *Type, raw any) (any, error) {
+	switch t.Kind {
+	case ArrayKind, SliceKind, TupleKind:
+		if object, ok := raw.(map[string]any); ok && t.Kind == TupleKind {
+			return parseJSONObject(t, object)
+		}
+
+		elements, ok := raw.([]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid %v value: expected JSON array, got %T", t, raw)
+		}
+
+		types, err := elementTypes(t, len(elements))
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, len(elements))
+		for i, element := range elements {
+			values[i], err = parseJSONValue(types[i], element)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return values, nil
+	case BoolKind:
+		if value, ok := raw.(bool); ok {
+			return value, nil
+		}
+	case StringKind:
+		switch value := raw.(type) {
+		case string:
+			return value, nil
+		case map[string]any:
+			// strings which are not valid UTF-8, as written by DecodeToJSON
+			if hexStr, ok := value["hex"].(string); ok && len(value) == 1 {
+				decoded, err := parseElementaryLiteral(&Type{Kind: BytesKind}, hexStr)
+				if err != nil {
+					return nil, err
+				}
+				return string(decoded.([]byte)), nil
+			}
+		}
+	default:
+		switch value := raw.(type) {
+		case string:
+			return parseElementaryLiteral(t, value)
+		case json.Number:
+			if t.Kind == IntKind || t.Kind == UintKind || t.Kind == FixedKind || t.Kind == UfixedKind {
+				return parseElementaryLiteral(t, value.String())
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("invalid %v value: %v (%T)", t, raw, raw)
+}
+
+// parseJSONObject parses a JSON object into the components of a tuple
+// with named components.
+func parseJSONObject(t *Type, object map[string]any) ([]any, error) {
+	if t.Names == nil {
+		return nil, fmt.Errorf("invalid %v value: JSON object requires named tuple components", t)
+	}
+
+	if len(object) != len(t.Components) {
+		return nil, fmt.Errorf("invalid %v value: expected %d fields, got %d", t, len(t.Components), len(object))
+	}
+
+	values := make([]any, len(t.Components))
+	for i, component := range t.Components {
+		raw, ok := object[t.Names[i]]
+		if !ok {
+			return nil, fmt.Errorf("invalid %v value: missing field %q", t, t.Names[i])
+		}
+
+		value, err := parseJSONValue(component, raw)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// parseElementaryLiteral parses a literal value of a numeric, address
+// or bytes type.
+func parseElementaryLiteral(t *Type, literal string) (any, error) {
+	literal = strings.TrimSpace(literal)
+
+	switch t.Kind {
+	case IntKind, UintKind:
+		value, err := parseIntegerLiteral(literal)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %q: %v", t, literal, err)
+		}
+
+		if err := checkIntegerRange(t.Kind == IntKind, t.Size, value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case FixedKind, UfixedKind:
+		decimal, err := parseDecimalLiteral(literal)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %q: %v", t, literal, err)
+		}
+
+		decimal, err = decimal.Rescale(t.Decimals)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %q: %v", t, literal, err)
+		}
+
+		if err := checkIntegerRange(t.Kind == FixedKind, t.Size, decimal.Value); err != nil {
+			return nil, fmt.Errorf("value out of allowed range: %v, %v", t, literal)
+		}
+
+		return decimal, nil
+	case AddressKind:
+		if !strings.HasPrefix(literal, "0x") || !common.IsHexAddress(literal) {
+			return nil, fmt.Errorf("invalid %v value %q", t, literal)
+		}
+
+		address := common.HexToAddress(literal)
+		return &address, nil
+	case BytesKind, FixedBytesKind:
+		if !strings.HasPrefix(literal, "0x") {
+			return nil, fmt.Errorf("invalid %v value %q: missing 0x prefix", t, literal)
+		}
+
+		value, err := hex.DecodeString(literal[2:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %q: %v", t, literal, err)
+		}
+
+		if t.Kind == FixedBytesKind && len(value) != t.Size {
+			return nil, fmt.Errorf("invalid %v value %q: expected %d bytes, got %d", t, literal, t.Size, len(value))
+		}
+
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type: %v", t)
+}
+
+// parseIntegerLiteral parses a decimal, 0x-prefixed hex or scientific
+// notation integer.
+func parseIntegerLiteral(literal string) (*big.Int, error) {
+	digits := literal
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		value, ok := new(big.Int).SetString(digits[2:], 16)
+		if !ok || strings.HasPrefix(digits[2:], "-") || strings.HasPrefix(digits[2:], "+") {
+			return nil, fmt.Errorf("invalid hex integer")
+		}
+		if strings.HasPrefix(literal, "-") {
+			value.Neg(value)
+		}
+		return value, nil
+	}
+
+	decimal, err := parseDecimalLiteral(literal)
+	if err != nil {
+		return nil, err
+	}
+
+	decimal, err = decimal.Rescale(0)
+	if err != nil {
+		return nil, fmt.Errorf("not an integer")
+	}
+
+	return decimal.Value, nil
+}
+
+// parseDecimalLiteral parses a decimal number, optionally in
+// scientific notation, i.e. `-1.5` or `1.5e18`.
+func parseDecimalLiteral(literal string) (*Decimal, error) {
+	mantissa, exponentStr, scientific := strings.Cut(strings.ToLower(literal), "e")
+
+	decimal, err := ParseDecimal(mantissa)
+	if err != nil {
+		return nil, err
+	}
+
+	if !scientific {
+		return decimal, nil
+	}
+
+	exponent, err := strconv.Atoi(exponentStr)
+	if err != nil || exponent > maxLiteralExponent || exponent < -maxLiteralExponent {
+		return nil, fmt.Errorf("invalid exponent: %q", exponentStr)
+	}
+
+	if exponent < 0 {
+		return &Decimal{Value: decimal.Value, Decimals: decimal.Decimals - exponent}, nil
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+	return &Decimal{Value: scale.Mul(scale, decimal.Value), Decimals: decimal.Decimals}, nil
+}
+
+// elementTypes returns the types of the n elements of an array or
+// tuple value, checking n against the type.
+func elementTypes(t *Type, n int) ([]*Type, error) {
+	switch t.Kind {
+	case TupleKind:
+		if n != len(t.Components) {
+			return nil, fmt.Errorf("tuple size mismatch for %v: expected %d, got %d", t, len(t.Components), n)
+		}
+		return t.Components, nil
+	case ArrayKind:
+		if n != t.Length {
+			return nil, fmt.Errorf("array size mismatch for %v: expected %d, got %d", t, t.Length, n)
+		}
+	}
+
+	return repeatType(t.Elem, n), nil
+}
+
+// splitElements splits the comma separated elements of an array or
+// tuple literal, ignoring commas in nested arrays, tuples and quoted
+// strings.
+func splitElements(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var elements []string
+	depth, start, quoted := 0, 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quoted:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				quoted = false
+			}
+		case c == '"':
+			quoted = true
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced %q", c)
+			}
+		case c == ',' && depth == 0:
+			elements = append(elements, s[start:i])
+			start = i + 1
+		}
+	}
+
+	if depth != 0 || quoted {
+		return nil, fmt.Errorf("unterminated array, tuple or string")
+	}
+
+	return append(elements, s[start:]), nil
+}
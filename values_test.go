@@ -0,0 +1,63 @@
+package abi_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleParseValue() {
+	value, err := abi.ParseValue("(address,uint256[],bytes2,int8)", "(0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789,[1e18,0xff],0x1234,-128)")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	_, err = abi.ParseValue("uint8", "256")
+	fmt.Println(err)
+
+	_, err = abi.ParseValue("uint256", "1.5")
+	fmt.Println(err)
+
+	_, err = abi.ParseValue("int256", "-+5")
+	fmt.Println(err)
+
+	_, err = abi.ParseValue("int256", "-+0x5")
+	fmt.Println(err)
+
+	// Output:
+	// [0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 [1000000000000000000 255] [18 52] -128]
+	// value out of allowed range: uint8, 256
+	// invalid uint256 value "1.5": not an integer
+	// invalid int256 value "-+5": invalid decimal: "-+5"
+	// invalid int256 value "-+0x5": invalid decimal: "-+0x5"
+}
+
+func ExampleParseValuesJSON() {
+	typeStrs := []string{"(address owner,uint256 amount)[]", "string", "bool", "fixed128x18"}
+	values, err := abi.ParseValuesJSON(typeStrs, json.RawMessage(`[
+		[{"owner": "0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789", "amount": "2.5e18"}],
+		"gm",
+		true,
+		-1.25
+	]`))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	encoded, err := abi.Encode(typeStrs, values...)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	decoded, err := abi.Decode(typeStrs, encoded)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(decoded)
+
+	// Output: [[[0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 2500000000000000000]] gm true -1.250000000000000000]
+}
@@ -0,0 +1,280 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// View is a lazy read-only view of an ABI encoded value. Offsets are
+// resolved on demand, i.e. `v.Index(2).Field(1).BigInt()` only reads
+// the words on its path, and bytes are returned as sub-slices of the
+// encoded data, without copying. Bounds are checked on every step: an
+// invalid step returns a View holding the error, which is then
+// returned by any accessor.
+type View struct {
+	t    *Type
+	data []byte // from the value encoding, i.e. its tail for dynamic values, to the end of the enclosing data
+	err  error
+}
+
+// NewView creates a view of data encoding a single value of given
+// type, as encoded by Encode.
+func NewView(typeStr string, data []byte) View {
+	return NewTupleView([]string{typeStr}, data).Field(0)
+}
+
+// NewTupleView creates a view of data encoding values of given types,
+// i.e. function parameters, accessed with Field.
+func NewTupleView(typeStrs []string, data []byte) View {
+	types, err := parseTypes(typeStrs)
+	if err != nil {
+		return View{err: err}
+	}
+
+	return View{t: &Type{Kind: TupleKind, Components: types}, data: data}
+}
+
+// Err returns the error of the view, if any.
+func (v View) Err() error {
+	return v.err
+}
+
+// Type returns the canonical type string of the viewed value.
+func (v View) Type() string {
+	if v.err != nil {
+		return ""
+	}
+
+	return v.t.String()
+}
+
+// Len returns the number of elements of an array, the number of
+// components of a tuple or the byte length of bytes and string.
+func (v View) Len() (int, error) {
+	if v.err != nil {
+		return 0, v.err
+	}
+
+	switch v.t.Kind {
+	case SliceKind:
+		_, length, err := v.elements()
+		return length, err
+	case ArrayKind:
+		return v.t.Length, nil
+	case TupleKind:
+		return len(v.t.Components), nil
+	case BytesKind, StringKind:
+		content, err := v.content()
+		return len(content), err
+	default:
+		return 0, fmt.Errorf("cannot get length of %v", v.t)
+	}
+}
+
+// Index returns a view of the element at given index of an array.
+func (v View) Index(i int) View {
+	if v.err != nil {
+		return v
+	}
+
+	if v.t.Kind != ArrayKind && v.t.Kind != SliceKind {
+		return View{err: fmt.Errorf("cannot index %v", v.t)}
+	}
+
+	region, length, err := v.elements()
+	if err != nil {
+		return View{err: err}
+	}
+
+	if i < 0 || i >= length {
+		return View{err: fmt.Errorf("index %d out of range for %v of length %d", i, v.t, length)}
+	}
+
+	return component(v.t.Elem, region, i*v.t.Elem.headSize())
+}
+
+// Field returns a view of the component at given index of a tuple.
+func (v View) Field(i int) View {
+	if v.err != nil {
+		return v
+	}
+
+	if v.t.Kind != TupleKind {
+		return View{err: fmt.Errorf("cannot get field of %v", v.t)}
+	}
+
+	if i < 0 || i >= len(v.t.Components) {
+		return View{err: fmt.Errorf("field %d out of range for %v", i, v.t)}
+	}
+
+	pos := 0
+	for _, t := range v.t.Components[:i] {
+		pos += t.headSize()
+	}
+
+	return component(v.t.Components[i], v.data, pos)
+}
+
+// Bytes returns the content of bytes and string values, and the bytes
+// of bytesN values, as a sub-slice of the encoded data.
+func (v View) Bytes() ([]byte, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	switch v.t.Kind {
+	case BytesKind, StringKind:
+		return v.content()
+	case FixedBytesKind:
+		word, err := v.word()
+		if err != nil {
+			return nil, err
+		}
+		return word[:v.t.Size], nil
+	default:
+		return nil, fmt.Errorf("cannot read %v as bytes", v.t)
+	}
+}
+
+// Text returns the value of a string.
+func (v View) Text() (string, error) {
+	if v.err != nil {
+		return "", v.err
+	}
+
+	if v.t.Kind != StringKind {
+		return "", fmt.Errorf("cannot read %v as string", v.t)
+	}
+
+	content, err := v.content()
+	return string(content), err
+}
+
+// BigInt returns the value of an integer.
+func (v View) BigInt() (*big.Int, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	if v.t.Kind != IntKind && v.t.Kind != UintKind {
+		return nil, fmt.Errorf("cannot read %v as integer", v.t)
+	}
+
+	word, err := v.word()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeInteger(v.t.Kind == IntKind, v.t.Size, word), nil
+}
+
+// Address returns the value of an address.
+func (v View) Address() (common.Address, error) {
+	if v.err != nil {
+		return common.Address{}, v.err
+	}
+
+	if v.t.Kind != AddressKind {
+		return common.Address{}, fmt.Errorf("cannot read %v as address", v.t)
+	}
+
+	word, err := v.word()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return common.BytesToAddress(word), nil
+}
+
+// Bool returns the value of a bool.
+func (v View) Bool() (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+
+	if v.t.Kind != BoolKind {
+		return false, fmt.Errorf("cannot read %v as bool", v.t)
+	}
+
+	word, err := v.word()
+	if err != nil {
+		return false, err
+	}
+
+	return word[31] == 1, nil
+}
+
+// Value decodes the viewed value, like DecodeTyped.
+func (v View) Value() (any, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	value, _, err := decoder{typed: true}.withStrings().decodeValue(v.t, v.data)
+	return value, err
+}
+
+// component returns a view of the value of given type whose head
+// starts at given position of a tuple encoding. Offsets of dynamic
+// values are relative to the beginning of the tuple encoding.
+func component(t *Type, tuple []byte, pos int) View {
+	if !t.IsDynamic() {
+		if pos+t.headSize() > len(tuple) {
+			return View{err: fmt.Errorf("data byte size is too short for %v. Length: %d", t, len(tuple)-pos)}
+		}
+
+		return View{t: t, data: tuple[pos:]}
+	}
+
+	offset, err := readSize(tuple, pos)
+	if err != nil {
+		return View{err: err}
+	}
+
+	return View{t: t, data: tuple[offset:]}
+}
+
+// elements returns the encoding of the elements of an array and their
+// number, checking that their heads are within bounds.
+func (v View) elements() ([]byte, int, error) {
+	region, length := v.data, v.t.Length
+	if v.t.Kind == SliceKind {
+		var err error
+		length, err = readSize(v.data, 0)
+		if err != nil {
+			return nil, 0, err
+		}
+		region = v.data[32:]
+	}
+
+	if elemSize := v.t.Elem.headSize(); elemSize > 0 && length > len(region)/elemSize {
+		return nil, 0, fmt.Errorf("array length out of bounds for %v: %d", v.t, length)
+	}
+
+	return region, length, nil
+}
+
+// content returns the content of a bytes or string value.
+func (v View) content() ([]byte, error) {
+	length, err := readSize(v.data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if 32+length > len(v.data) {
+		return nil, fmt.Errorf("data byte size is too short for %v. Length: %d", v.t, len(v.data)-32)
+	}
+
+	return v.data[32 : 32+length], nil
+}
+
+// word returns the 32-byte word of a static elementary value.
+func (v View) word() ([]byte, error) {
+	if len(v.data) < 32 {
+		return nil, fmt.Errorf("data byte size is too short for %v. Length: %d", v.t, len(v.data))
+	}
+
+	return v.data[:32], nil
+}
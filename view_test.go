@@ -0,0 +1,93 @@
+package abi_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/omnes-tech/abi"
+)
+
+func ExampleNewView() {
+	owner := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	entries := make([]any, 1000)
+	for i := range entries {
+		entries[i] = []any{owner, big.NewInt(int64(i) * 100), []byte(fmt.Sprintf("entry %d", i))}
+	}
+
+	data, err := abi.Encode([]string{"(address,uint256,bytes)[]"}, entries)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	view := abi.NewView("(address,uint256,bytes)[]", data)
+
+	length, err := view.Len()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	amount, err := view.Index(2).Field(1).BigInt()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	memo, err := view.Index(999).Field(2).Bytes()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(length, amount, string(memo))
+
+	// Output: 1000 200 entry 999
+}
+
+func ExampleNewView_errors() {
+	data, err := abi.Encode([]string{"uint256[]"}, []any{big.NewInt(1), big.NewInt(2)})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	view := abi.NewView("uint256[]", data)
+
+	_, err = view.Index(2).BigInt()
+	fmt.Println(err)
+
+	_, err = view.Index(0).Address()
+	fmt.Println(err)
+
+	_, err = abi.NewView("uint256[]", data[:64]).Index(0).BigInt()
+	fmt.Println(err)
+
+	// Output:
+	// index 2 out of range for uint256[] of length 2
+	// cannot read uint256 as address
+	// array length out of bounds for uint256[]: 2
+}
+
+func ExampleNewTupleView() {
+	data, err := abi.EncodeWithSignature(
+		"transfer(address,uint256)",
+		common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"),
+		big.NewInt(1000),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	view := abi.NewTupleView([]string{"address", "uint256"}, data[4:])
+
+	to, err := view.Field(0).Address()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	amount, err := view.Field(1).Value()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(to, amount)
+
+	// Output: 0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789 1000
+}